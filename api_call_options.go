@@ -9,12 +9,14 @@ import (
 
 // GetOptions are the optional query parameters for a GET request.
 type GetOptions struct {
+	// Fields selects the fields the API returns, see [FieldSelector] for checking them.
 	Fields []string `url:"fields,omitempty,comma"`
 	Limit  int      `url:"limit,omitempty"`
 	Offset int      `url:"offset,omitempty"`
-	Type   []string `url:"type,ommitempty"`
-	Status []string `url:"status,ommitempty"`
-	// QueryParams for API URL, used for arbitrary filters
+	Type   []string `url:"type,omitempty"`
+	Status []string `url:"status,omitempty"`
+	// QueryParams for API URL, used for arbitrary filters.
+	// Prefer typed filters, e.g. [ServerFilter], where available.
 	QueryParams map[string]string `url:"-"`
 }
 
@@ -1,4 +1,9 @@
 // Package backoff provides backoff calculation functions.
+//
+// Strategies, e.g. [ExponentialBackoff], generate the delays, and
+// combinators, e.g. [Cap] and [MaxAttempts], bound them. The resulting
+// [Func] can be used by the client, e.g. with cherrygo.WithRetryBackoff,
+// and by [Retry] for other retry loops.
 package backoff
 
 import (
@@ -6,12 +11,87 @@ import (
 	"math/rand/v2"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
-// Func generates backoff delays.
+// Func generates backoff delays. Attempts is the amount of failed attempts
+// before the delay, minus one, i.e. it's 0 for the delay after the first attempt.
+// A Func returns [Stop] if there shouldn't be another attempt.
 type Func func(attempts int, resp *http.Response) time.Duration
 
+// Stop is returned by a [Func] to give up retrying.
+const Stop time.Duration = -1
+
+// Rand is a source of random numbers for jitter, e.g. a [*rand.Rand]
+// with a fixed seed for deterministic tests.
+type Rand interface {
+	// Int64N returns a number in [0, n). n is positive.
+	Int64N(n int64) int64
+}
+
+// Option configures a strategy.
+type Option func(*config)
+
+type config struct {
+	rand          Rand
+	maxRetryAfter time.Duration
+}
+
+// WithRand makes a strategy draw jitter from r.
+// Strategies use the global source of [math/rand/v2] by default.
+func WithRand(r Rand) Option {
+	return func(c *config) {
+		c.rand = &lockedRand{r: r}
+	}
+}
+
+// DefaultMaxRetryAfter is the longest `Retry-After` delay
+// [RateLimitedExponentialBackoff] waits for by default.
+const DefaultMaxRetryAfter = 5 * time.Minute
+
+// WithMaxRetryAfter limits the `Retry-After` delays that
+// [RateLimitedExponentialBackoff] waits for to d, instead of [DefaultMaxRetryAfter].
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *config) {
+		c.maxRetryAfter = d
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{rand: globalRand{}, maxRetryAfter: DefaultMaxRetryAfter}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// between returns a duration in [lo, hi].
+func (c config) between(lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(c.rand.Int64N(int64(hi-lo)+1))
+}
+
+type globalRand struct{}
+
+func (globalRand) Int64N(n int64) int64 {
+	return rand.Int64N(n)
+}
+
+// lockedRand makes a source safe for the concurrent use of the client.
+type lockedRand struct {
+	mu sync.Mutex
+	r  Rand
+}
+
+func (l *lockedRand) Int64N(n int64) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Int64N(n)
+}
+
 // ExponentialBackoffConfig is the configuration for exponential backoff.
 type ExponentialBackoffConfig struct {
 	Base       time.Duration
@@ -22,36 +102,155 @@ type ExponentialBackoffConfig struct {
 // RateLimitedExponentialBackoff returns an backoff function
 // that prioritizes `Retry-After` headers, defaulting to exponential backoff
 // if they're not found. Adds partial jitter.
-func RateLimitedExponentialBackoff(cfg ExponentialBackoffConfig) Func {
-	exp := ExponentialBackoff(cfg)
+//
+// Delays of `Retry-After` headers are limited, see [WithMaxRetryAfter],
+// and dates in the past mean retrying right away.
+func RateLimitedExponentialBackoff(cfg ExponentialBackoffConfig, opts ...Option) Func {
+	c := newConfig(opts)
+	exp := ExponentialBackoff(cfg, opts...)
 	return func(attempts int, resp *http.Response) time.Duration {
-		backoff, ok := parseRetryAfter(resp)
+		backoff, ok := RetryAfter(resp)
 		if ok {
-			return backoff
+			return min(backoff, c.maxRetryAfter)
 		}
 
 		return exp(attempts, resp)
 	}
 }
 
-// ExponentialBackoff returns an exponential backoff with partial jitter.
-func ExponentialBackoff(cfg ExponentialBackoffConfig) Func {
+// ExponentialBackoff returns an exponential backoff with partial jitter,
+// i.e. a delay between half and all of the exponential delay.
+func ExponentialBackoff(cfg ExponentialBackoffConfig, opts ...Option) Func {
+	c := newConfig(opts)
 	return func(attempts int, _ *http.Response) time.Duration {
-		backoffSeconds := cfg.Base.Seconds() * math.Pow(cfg.Multiplier, float64(attempts))
-
-		// Guard against overflow on high iterations.
-		if math.IsNaN(backoffSeconds) ||
-			math.IsInf(backoffSeconds, 0) ||
-			backoffSeconds <= 0 ||
-			backoffSeconds >= cfg.Cap.Seconds() {
+		backoff, capped := cfg.delay(attempts)
+		if capped {
 			return cfg.Cap
 		}
-		backoff := time.Duration(backoffSeconds * float64(time.Second))
-		return jitter(backoff)
+		return c.between(backoff/2, backoff)
+	}
+}
+
+// FullJitter returns an exponential backoff with full jitter,
+// i.e. a delay between zero and the exponential delay.
+func FullJitter(cfg ExponentialBackoffConfig, opts ...Option) Func {
+	c := newConfig(opts)
+	return func(attempts int, _ *http.Response) time.Duration {
+		backoff, _ := cfg.delay(attempts)
+		return c.between(0, backoff)
+	}
+}
+
+// delay returns the exponential delay of attempts, and whether it's capped.
+func (cfg ExponentialBackoffConfig) delay(attempts int) (time.Duration, bool) {
+	backoffSeconds := cfg.Base.Seconds() * math.Pow(cfg.Multiplier, float64(attempts))
+
+	// Guard against overflow on high iterations.
+	if math.IsNaN(backoffSeconds) ||
+		math.IsInf(backoffSeconds, 0) ||
+		backoffSeconds <= 0 ||
+		backoffSeconds >= cfg.Cap.Seconds() {
+		return cfg.Cap, true
+	}
+	return time.Duration(backoffSeconds * float64(time.Second)), false
+}
+
+// minDecorrelatedBase is the smallest base of [DecorrelatedJitter].
+const minDecorrelatedBase = time.Millisecond
+
+// DecorrelatedJitter returns a backoff with decorrelated jitter, where each
+// delay is between base and three times the previous delay, up to limit.
+// A base below a millisecond is raised to a millisecond, since delays only
+// grow from it and a zero base would retry without waiting. If limit is
+// below base, every delay is limit.
+//
+// Rather than remembering the previous delay, which would be shared by
+// concurrent retries, each call draws the whole sequence up to attempts,
+// so delays follow the same distribution without state.
+func DecorrelatedJitter(base, limit time.Duration, opts ...Option) Func {
+	c := newConfig(opts)
+	base = max(base, minDecorrelatedBase)
+	return func(attempts int, _ *http.Response) time.Duration {
+		delay := base
+		// Once delays reach the limit, their distribution no longer changes.
+		for range min(attempts+1, 64) {
+			upper := delay * 3
+			if upper < delay || upper > limit {
+				upper = limit
+			}
+			delay = c.between(base, upper)
+		}
+		return min(delay, limit)
+	}
+}
+
+// Constant returns a backoff that always waits for delay.
+func Constant(delay time.Duration) Func {
+	return func(int, *http.Response) time.Duration {
+		return delay
+	}
+}
+
+// Linear returns a backoff that waits for base, and step longer after each attempt.
+func Linear(base, step time.Duration) Func {
+	return func(attempts int, _ *http.Response) time.Duration {
+		if step > 0 && time.Duration(attempts) > (math.MaxInt64-base)/step {
+			return math.MaxInt64
+		}
+		return base + step*time.Duration(attempts)
 	}
 }
 
-func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+// Cap limits the delays of f to limit.
+func Cap(f Func, limit time.Duration) Func {
+	return func(attempts int, resp *http.Response) time.Duration {
+		delay := f(attempts, resp)
+		if delay == Stop {
+			return Stop
+		}
+		return min(delay, limit)
+	}
+}
+
+// MaxAttempts stops retrying once there have been n attempts in total.
+func MaxAttempts(f Func, n int) Func {
+	return func(attempts int, resp *http.Response) time.Duration {
+		if attempts+1 >= n {
+			return Stop
+		}
+		return f(attempts, resp)
+	}
+}
+
+// MaxElapsed stops retrying once the next attempt would start more than
+// limit after the first call, i.e. the one with attempts 0.
+//
+// Unlike the other strategies and combinators, the returned Func has state,
+// so it should only be used by one retry loop at a time, e.g. by [Retry].
+// A client retries concurrent requests, so bound its calls with
+// a context deadline instead.
+func MaxElapsed(f Func, limit time.Duration) Func {
+	var mu sync.Mutex
+	var start time.Time
+	return func(attempts int, resp *http.Response) time.Duration {
+		mu.Lock()
+		if attempts == 0 || start.IsZero() {
+			start = time.Now()
+		}
+		elapsed := time.Since(start)
+		mu.Unlock()
+
+		delay := f(attempts, resp)
+		if delay == Stop || elapsed+delay > limit {
+			return Stop
+		}
+		return delay
+	}
+}
+
+// RetryAfter returns the delay of the `Retry-After` header of resp, if it has
+// a valid one. Dates in the past are a delay of zero, so it's never negative.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
 	if resp == nil {
 		return 0, false
 	}
@@ -59,19 +258,21 @@ func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
 	// Retry-After can be an integer with seconds or an HTTP date.
 	d := resp.Header.Get("Retry-After")
 
-	seconds, err := strconv.Atoi(d)
+	seconds, err := strconv.ParseInt(d, 10, 64)
 	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return math.MaxInt64, true
+		}
 		return time.Duration(seconds) * time.Second, true
 	}
 
 	date, err := http.ParseTime(d)
 	if err == nil {
-		return time.Until(date), true
+		return max(time.Until(date), 0), true
 	}
 
 	return 0, false
 }
-
-func jitter(base time.Duration) time.Duration {
-	return time.Duration(rand.Int64N(int64(base)/2)) + base/2
-}
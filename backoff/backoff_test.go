@@ -1,6 +1,8 @@
 package backoff_test
 
 import (
+	"math"
+	"math/rand/v2"
 	"net/http"
 	"testing"
 	"time"
@@ -96,3 +98,151 @@ func TestExponentialBackoffNoOverflow(t *testing.T) {
 	got := fn(1000, nil)
 	assert.Equal(t, 60*time.Second, got)
 }
+
+func newRand() backoff.Option {
+	return backoff.WithRand(rand.New(rand.NewPCG(1, 2)))
+}
+
+func TestWithRandIsDeterministic(t *testing.T) {
+	cfg := backoff.ExponentialBackoffConfig{Base: time.Second, Cap: time.Minute, Multiplier: 2}
+
+	for name, strategy := range map[string]func(...backoff.Option) backoff.Func{
+		"exponential": func(opts ...backoff.Option) backoff.Func { return backoff.ExponentialBackoff(cfg, opts...) },
+		"full jitter": func(opts ...backoff.Option) backoff.Func { return backoff.FullJitter(cfg, opts...) },
+		"decorrelated": func(opts ...backoff.Option) backoff.Func {
+			return backoff.DecorrelatedJitter(time.Second, time.Minute, opts...)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a, b := strategy(newRand()), strategy(newRand())
+			for i := range 10 {
+				assert.Equal(t, a(i, nil), b(i, nil))
+			}
+		})
+	}
+}
+
+func TestFullJitter(t *testing.T) {
+	fn := backoff.FullJitter(backoff.ExponentialBackoffConfig{
+		Base:       time.Second,
+		Cap:        10 * time.Second,
+		Multiplier: 2,
+	}, newRand())
+
+	for i := range 1000 {
+		attempts := i % 8
+		upper := min(time.Second<<attempts, 10*time.Second)
+		d := fn(attempts, nil)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, upper)
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	fn := backoff.DecorrelatedJitter(time.Second, 20*time.Second, newRand())
+
+	for i := range 1000 {
+		attempts := i % 8
+		d := fn(attempts, nil)
+		assert.GreaterOrEqual(t, d, time.Second)
+		// Each delay is at most three times the previous one.
+		upper := time.Second
+		for range attempts + 1 {
+			upper *= 3
+		}
+		assert.LessOrEqual(t, d, min(upper, 20*time.Second))
+	}
+
+	assert.LessOrEqual(t, fn(1<<30, nil), 20*time.Second)
+
+	// A zero base would never wait.
+	zero := backoff.DecorrelatedJitter(0, time.Second, newRand())
+	for i := range 100 {
+		assert.GreaterOrEqual(t, zero(i%8, nil), time.Millisecond)
+	}
+
+	// A limit below the base limits every delay.
+	low := backoff.DecorrelatedJitter(time.Second, 100*time.Millisecond, newRand())
+	assert.Equal(t, 100*time.Millisecond, low(0, nil))
+	assert.Equal(t, 100*time.Millisecond, low(5, nil))
+}
+
+func TestConstantAndLinear(t *testing.T) {
+	constant := backoff.Constant(3 * time.Second)
+	assert.Equal(t, 3*time.Second, constant(0, nil))
+	assert.Equal(t, 3*time.Second, constant(7, nil))
+
+	linear := backoff.Linear(time.Second, 2*time.Second)
+	assert.Equal(t, time.Second, linear(0, nil))
+	assert.Equal(t, 7*time.Second, linear(3, nil))
+	assert.Equal(t, time.Duration(math.MaxInt64), linear(math.MaxInt, nil))
+}
+
+func TestCombinators(t *testing.T) {
+	capped := backoff.Cap(backoff.Linear(time.Second, time.Second), 3*time.Second)
+	assert.Equal(t, 2*time.Second, capped(1, nil))
+	assert.Equal(t, 3*time.Second, capped(5, nil))
+
+	limited := backoff.MaxAttempts(backoff.Constant(time.Second), 3)
+	assert.Equal(t, time.Second, limited(0, nil))
+	assert.Equal(t, time.Second, limited(1, nil))
+	assert.Equal(t, backoff.Stop, limited(2, nil))
+
+	assert.Equal(t, backoff.Stop, backoff.Cap(limited, time.Millisecond)(2, nil))
+}
+
+func TestMaxElapsed(t *testing.T) {
+	fn := backoff.MaxElapsed(backoff.Constant(20*time.Millisecond), 50*time.Millisecond)
+
+	assert.Equal(t, 20*time.Millisecond, fn(0, nil))
+	time.Sleep(40 * time.Millisecond)
+	assert.Equal(t, backoff.Stop, fn(1, nil))
+
+	// A new sequence starts over.
+	assert.Equal(t, 20*time.Millisecond, fn(0, nil))
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		retryAfter string
+		want       time.Duration
+		wantOK     bool
+	}{
+		{retryAfter: "5", want: 5 * time.Second, wantOK: true},
+		{retryAfter: "0", want: 0, wantOK: true},
+		{retryAfter: "-5"},
+		{retryAfter: "soon"},
+		{retryAfter: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), want: 0, wantOK: true},
+		{retryAfter: "99999999999999999", want: math.MaxInt64, wantOK: true},
+	}
+
+	for _, td := range cases {
+		t.Run(td.retryAfter, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{"Retry-After": {td.retryAfter}}}
+			got, ok := backoff.RetryAfter(resp)
+			assert.Equal(t, td.wantOK, ok)
+			assert.Equal(t, td.want, got)
+		})
+	}
+
+	_, ok := backoff.RetryAfter(nil)
+	assert.False(t, ok)
+}
+
+func TestRateLimitedExponentialBackoffClampsRetryAfter(t *testing.T) {
+	cfg := backoff.ExponentialBackoffConfig{Base: time.Second, Cap: time.Minute, Multiplier: 2}
+	resp := func(retryAfter string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": {retryAfter}}}
+	}
+
+	fn := backoff.RateLimitedExponentialBackoff(cfg)
+	assert.Equal(t, backoff.DefaultMaxRetryAfter, fn(0, resp("86400")))
+	assert.Equal(t, time.Duration(0), fn(0, resp(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))))
+
+	// Invalid values fall back to exponential backoff.
+	assert.GreaterOrEqual(t, fn(0, resp("-1")), 500*time.Millisecond)
+
+	fn = backoff.RateLimitedExponentialBackoff(cfg, backoff.WithMaxRetryAfter(10*time.Second))
+	assert.Equal(t, 10*time.Second, fn(0, resp("60")))
+	assert.Equal(t, 5*time.Second, fn(0, resp("5")))
+}
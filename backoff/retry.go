@@ -0,0 +1,66 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Retry calls fn until it succeeds, returning its result. It waits between
+// calls for the delays of policy, called without a response, and gives up
+// when policy returns [Stop], fn returns a [Permanent] error or ctx is done.
+//
+// Giving up returns the last error of fn, wrapped with the context error
+// if ctx is done.
+func Retry[T any](ctx context.Context, policy Func, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	for attempts := 0; ; attempts++ {
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		v, err := fn(ctx)
+		if err == nil {
+			return v, nil
+		}
+
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return zero, perm.err
+		}
+
+		delay := policy(attempts, nil)
+		if delay == Stop {
+			return zero, err
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return zero, fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-t.C:
+		}
+	}
+}
+
+// Permanent wraps err so [Retry] returns it without trying again.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,62 @@
+package backoff_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = errors.New("transient")
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	v, err := backoff.Retry(t.Context(), backoff.Constant(time.Millisecond), func(context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return 0, errTransient
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryStop(t *testing.T) {
+	calls := 0
+	policy := backoff.MaxAttempts(backoff.Constant(time.Millisecond), 4)
+	_, err := backoff.Retry(t.Context(), policy, func(context.Context) (string, error) {
+		calls++
+		return "", errTransient
+	})
+	assert.ErrorIs(t, err, errTransient)
+	assert.Equal(t, 4, calls)
+}
+
+func TestRetryPermanent(t *testing.T) {
+	errFatal := errors.New("fatal")
+	calls := 0
+	_, err := backoff.Retry(t.Context(), backoff.Constant(time.Millisecond), func(context.Context) (string, error) {
+		calls++
+		return "", backoff.Permanent(errFatal)
+	})
+	assert.Equal(t, errFatal, err)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, backoff.Permanent(nil))
+}
+
+func TestRetryContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := backoff.Retry(ctx, backoff.Constant(time.Hour), func(context.Context) (string, error) {
+		return "", errTransient
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, errTransient)
+}
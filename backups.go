@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,13 +12,15 @@ const baseBackupPath = "/v1/backup-storages"
 // BackupsService is an interface for interfacing with the the Backup Storage endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Backup-Storage
 type BackupsService interface {
-	ListPlans(ctx context.Context, opts *GetOptions) ([]BackupStoragePlan, *Response, error)
-	ListBackups(ctx context.Context, projectID int, opts *GetOptions) ([]BackupStorage, *Response, error)
-	Get(ctx context.Context, backupID int, opts *GetOptions) (BackupStorage, *Response, error)
-	Create(ctx context.Context, serverID int, request *CreateBackup) (BackupStorage, *Response, error)
-	Update(ctx context.Context, id int, request *UpdateBackupStorage) (BackupStorage, *Response, error)
-	UpdateBackupMethod(ctx context.Context, id int, method string, request *UpdateBackupMethod) ([]BackupMethod, *Response, error)
-	Delete(ctx context.Context, backupID int) (*Response, error)
+	ListPlans(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]BackupStoragePlan, *Response, error)
+	AllPlans(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[BackupStoragePlan, error]
+	ListBackups(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]BackupStorage, *Response, error)
+	AllBackups(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[BackupStorage, error]
+	Get(ctx context.Context, backupID int, opts *GetOptions, callOpts ...CallOption) (BackupStorage, *Response, error)
+	Create(ctx context.Context, serverID int, request *CreateBackup, callOpts ...CallOption) (BackupStorage, *Response, error)
+	Update(ctx context.Context, id int, request *UpdateBackupStorage, callOpts ...CallOption) (BackupStorage, *Response, error)
+	UpdateBackupMethod(ctx context.Context, id int, method string, request *UpdateBackupMethod, callOpts ...CallOption) ([]BackupMethod, *Response, error)
+	Delete(ctx context.Context, backupID int, callOpts ...CallOption) (*Response, error)
 }
 
 // BackupsClient makes backup storage related API requests.
@@ -103,11 +106,11 @@ type UpdateBackupMethod struct {
 }
 
 // ListPlans lists backups storage plans.
-func (s *BackupsClient) ListPlans(ctx context.Context, opts *GetOptions) ([]BackupStoragePlan, *Response, error) {
+func (s *BackupsClient) ListPlans(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]BackupStoragePlan, *Response, error) {
 	var trans []BackupStoragePlan
 
 	path := opts.WithQuery("/v1/backup-storage-plans")
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.ListPlans"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -116,12 +119,19 @@ func (s *BackupsClient) ListPlans(ctx context.Context, opts *GetOptions) ([]Back
 	return trans, resp, err
 }
 
+// AllPlans iterates over all backup storage plans, fetching pages lazily.
+func (s *BackupsClient) AllPlans(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[BackupStoragePlan, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]BackupStoragePlan, *Response, error) {
+		return s.ListPlans(ctx, opts, callOpts...)
+	})
+}
+
 // ListBackups lists backup storage instances.
-func (s *BackupsClient) ListBackups(ctx context.Context, projectID int, opts *GetOptions) ([]BackupStorage, *Response, error) {
+func (s *BackupsClient) ListBackups(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]BackupStorage, *Response, error) {
 	var trans []BackupStorage
 
 	path := opts.WithQuery(fmt.Sprintf("/v1/projects/%d/backup-storages", projectID))
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.ListBackups"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -130,12 +140,19 @@ func (s *BackupsClient) ListBackups(ctx context.Context, projectID int, opts *Ge
 	return trans, resp, err
 }
 
+// AllBackups iterates over all project backup storage instances, fetching pages lazily.
+func (s *BackupsClient) AllBackups(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[BackupStorage, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]BackupStorage, *Response, error) {
+		return s.ListBackups(ctx, projectID, opts, callOpts...)
+	})
+}
+
 // Get backup storage instance.
-func (s *BackupsClient) Get(ctx context.Context, backupID int, opts *GetOptions) (BackupStorage, *Response, error) {
+func (s *BackupsClient) Get(ctx context.Context, backupID int, opts *GetOptions, callOpts ...CallOption) (BackupStorage, *Response, error) {
 	var trans BackupStorage
 
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", baseBackupPath, backupID))
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return BackupStorage{}, nil, err
 	}
@@ -145,12 +162,18 @@ func (s *BackupsClient) Get(ctx context.Context, backupID int, opts *GetOptions)
 }
 
 // Create backup storage instance.
-func (s *BackupsClient) Create(ctx context.Context, serverID int, request *CreateBackup) (BackupStorage, *Response, error) {
+func (s *BackupsClient) Create(ctx context.Context, serverID int, request *CreateBackup, callOpts ...CallOption) (BackupStorage, *Response, error) {
+	if request != nil && s.client.shouldValidate(callOpts) {
+		if err := request.Validate(); err != nil {
+			return BackupStorage{}, nil, err
+		}
+	}
+
 	var trans BackupStorage
 
 	path := fmt.Sprintf("/v1/servers/%d/backup-storages", serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.Create"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return BackupStorage{}, nil, err
 	}
@@ -160,12 +183,12 @@ func (s *BackupsClient) Create(ctx context.Context, serverID int, request *Creat
 }
 
 // Update backup storage instance.
-func (s *BackupsClient) Update(ctx context.Context, id int, request *UpdateBackupStorage) (BackupStorage, *Response, error) {
+func (s *BackupsClient) Update(ctx context.Context, id int, request *UpdateBackupStorage, callOpts ...CallOption) (BackupStorage, *Response, error) {
 	var trans BackupStorage
 
 	path := fmt.Sprintf("%s/%d", baseBackupPath, id)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return BackupStorage{}, nil, err
 	}
@@ -175,11 +198,11 @@ func (s *BackupsClient) Update(ctx context.Context, id int, request *UpdateBacku
 }
 
 // UpdateBackupMethod updates backup storage instance access methods.
-func (s *BackupsClient) UpdateBackupMethod(ctx context.Context, id int, method string, request *UpdateBackupMethod) ([]BackupMethod, *Response, error) {
+func (s *BackupsClient) UpdateBackupMethod(ctx context.Context, id int, method string, request *UpdateBackupMethod, callOpts ...CallOption) ([]BackupMethod, *Response, error) {
 	var trans []BackupMethod
 
 	path := fmt.Sprintf("%s/%d/methods/%s", baseBackupPath, id, method)
-	req, err := s.client.NewRequest(ctx, http.MethodPatch, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.UpdateBackupMethod"), http.MethodPatch, path, request, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -189,9 +212,9 @@ func (s *BackupsClient) UpdateBackupMethod(ctx context.Context, id int, method s
 }
 
 // Delete backup storage instance.
-func (s *BackupsClient) Delete(ctx context.Context, backupID int) (*Response, error) {
+func (s *BackupsClient) Delete(ctx context.Context, backupID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d", baseBackupPath, backupID)
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Backups.Delete"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
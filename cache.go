@@ -0,0 +1,217 @@
+package cherrygo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// DefaultCacheTTLs returns the default lifetimes of cached responses,
+// by operation, for the near-static catalog endpoints.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"Plans.List":         10 * time.Minute,
+		"Images.List":        10 * time.Minute,
+		"Regions.List":       time.Hour,
+		"Servers.ListCycles": time.Hour,
+		"Backups.ListPlans":  time.Hour,
+	}
+}
+
+// WithCache enables an in-memory cache of GET responses.
+//
+// ttls sets how long responses stay fresh, by operation, e.g. "Plans.List".
+// Responses of operations without a TTL are not cached. If ttls is nil,
+// [DefaultCacheTTLs] are used.
+//
+// Responses are cached by URL and API key, so clients with different keys
+// never share entries. Fresh responses are served without a request.
+// Stale responses are revalidated with If-None-Match and If-Modified-Since
+// headers, when the API supplied an ETag or Last-Modified header, and served
+// again if it replies 304 Not Modified. Any request other than a GET
+// invalidates the whole cache. Responses served from the cache have
+// [Meta.Cached] set.
+func WithCache(ttls map[string]time.Duration) ClientOpt {
+	return func(c *options) error {
+		if ttls == nil {
+			ttls = DefaultCacheTTLs()
+		}
+
+		for op, ttl := range ttls {
+			if !isReadOperation(op) {
+				return fmt.Errorf("can't cache responses of %q, not a read operation", op)
+			}
+			if ttl <= 0 {
+				return fmt.Errorf("cache TTL of %q must be positive, got %v", op, ttl)
+			}
+		}
+
+		cache := &responseCache{
+			ttls:    ttls,
+			entries: make(map[string]*cacheEntry),
+			now:     time.Now,
+		}
+		c.clientOpts = append(c.clientOpts, client.WithCallMiddleware(cache.middleware))
+		return nil
+	}
+}
+
+func isReadOperation(op string) bool {
+	for _, r := range routes {
+		if r.method == http.MethodGet && (r.operation == op || slices.Contains(sharedRoutes[r.operation], op)) {
+			return true
+		}
+	}
+	return false
+}
+
+type responseCache struct {
+	ttls map[string]time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+
+	// generation is incremented on every invalidation, so responses
+	// to requests that raced with a mutation are not stored.
+	generation uint64
+}
+
+type cacheEntry struct {
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func (e *cacheEntry) revalidatable() bool {
+	return e.header.Get("ETag") != "" || e.header.Get("Last-Modified") != ""
+}
+
+// cacheKey identifies a response by the request URL and a hash of its credentials.
+func cacheKey(req *http.Request) string {
+	identity := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.Method + " " + req.URL.String() + " " + hex.EncodeToString(identity[:8])
+}
+
+func (c *responseCache) middleware(next client.Doer) client.Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			defer c.clear()
+			return next.Do(req)
+		}
+
+		ttl, ok := c.ttls[requestRoute(req).operation]
+		if !ok {
+			return next.Do(req)
+		}
+
+		key := cacheKey(req)
+		entry, generation := c.get(key)
+		if entry != nil && c.now().Before(entry.expires) {
+			return c.serve(req, entry), nil
+		}
+
+		if entry != nil && entry.revalidatable() {
+			req = req.Clone(req.Context())
+			if etag := entry.header.Get("ETag"); etag != "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if modified := entry.header.Get("Last-Modified"); modified != "" {
+				req.Header.Set("If-Modified-Since", modified)
+			}
+		}
+
+		resp, err := next.Do(req)
+		if err != nil {
+			return resp, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && entry != nil:
+			_ = resp.Body.Close()
+			entry = c.refresh(key, entry, resp.Header, ttl, generation)
+			return c.serve(req, entry), nil
+		case resp.StatusCode == http.StatusOK:
+			body, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			c.put(key, &cacheEntry{
+				header:  resp.Header.Clone(),
+				body:    body,
+				expires: c.now().Add(ttl),
+			}, generation)
+		}
+
+		return resp, nil
+	})
+}
+
+func (c *responseCache) serve(req *http.Request, entry *cacheEntry) *http.Response {
+	if stats := client.StatsFromContext(req.Context()); stats != nil {
+		stats.Cached = true
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}
+}
+
+func (c *responseCache) get(key string) (*cacheEntry, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key], c.generation
+}
+
+// put stores an entry, unless the cache was invalidated since generation.
+func (c *responseCache) put(key string, entry *cacheEntry, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation == c.generation {
+		c.entries[key] = entry
+	}
+}
+
+// refresh extends the lifetime of a revalidated entry, updating its
+// headers with the ones of the 304 response.
+func (c *responseCache) refresh(key string, entry *cacheEntry, header http.Header, ttl time.Duration, generation uint64) *cacheEntry {
+	refreshed := &cacheEntry{
+		header:  entry.header.Clone(),
+		body:    entry.body,
+		expires: c.now().Add(ttl),
+	}
+	for _, name := range []string{"ETag", "Last-Modified", "Date"} {
+		if v := header.Get(name); v != "" {
+			refreshed.header.Set(name, v)
+		}
+	}
+
+	c.put(key, refreshed, generation)
+	return refreshed
+}
+
+func (c *responseCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.generation++
+}
@@ -0,0 +1,181 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheFresh(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithCache(nil))
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/regions", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, err := fmt.Fprint(w, `[{"id": 1, "slug": "eu_nord_1"}]`)
+		require.NoError(t, err)
+	})
+
+	regions, resp, err := c.Regions.List(t.Context(), nil)
+	require.NoError(t, err)
+	assert.False(t, resp.Cached)
+	assert.Len(t, regions, 1)
+
+	regions, resp, err = c.Regions.List(t.Context(), nil)
+	require.NoError(t, err)
+	assert.True(t, resp.Cached)
+	assert.Equal(t, "eu_nord_1", regions[0].Slug)
+	assert.Equal(t, 1, calls)
+
+	// Another API key must not see the cached response.
+	c.APIKey = "other"
+	_, resp, err = c.Regions.List(t.Context(), nil)
+	require.NoError(t, err)
+	assert.False(t, resp.Cached)
+	assert.Equal(t, 2, calls)
+
+	// Another URL is another entry.
+	_, resp, err = c.Regions.List(t.Context(), &GetOptions{Limit: 1})
+	require.NoError(t, err)
+	assert.False(t, resp.Cached)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithCache(map[string]time.Duration{
+		"Plans.List": time.Nanosecond,
+	}))
+	require.NoError(t, err)
+
+	var conditions []string
+	mux.HandleFunc("/v1/plans", func(w http.ResponseWriter, r *http.Request) {
+		conditions = append(conditions, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, err := fmt.Fprint(w, `[{"id": 1, "slug": "cloud_vps_1"}]`)
+		require.NoError(t, err)
+	})
+
+	_, resp, err := c.Plans.List(t.Context(), 0, nil)
+	require.NoError(t, err)
+	assert.False(t, resp.Cached)
+
+	plans, resp, err := c.Plans.List(t.Context(), 0, nil)
+	require.NoError(t, err)
+	assert.True(t, resp.Cached)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "cloud_vps_1", plans[0].Slug)
+
+	assert.Equal(t, []string{"", `"v1"`}, conditions)
+}
+
+func TestCacheStaleWithoutValidators(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithCache(map[string]time.Duration{
+		"Servers.ListCycles": time.Nanosecond,
+	}))
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/cycles", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		assert.Empty(t, r.Header.Get("If-Modified-Since"))
+		_, err := fmt.Fprint(w, `[{"id": 1, "slug": "hourly"}]`)
+		require.NoError(t, err)
+	})
+
+	for range 2 {
+		_, resp, err := c.Servers.ListCycles(t.Context(), nil)
+		require.NoError(t, err)
+		assert.False(t, resp.Cached)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithCache(nil))
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/backup-storage-plans", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, err := fmt.Fprint(w, `[]`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/servers/1/backup-storages", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Backups.ListPlans(t.Context(), nil)
+	require.NoError(t, err)
+	_, _, err = c.Backups.Create(t.Context(), 1, &CreateBackup{})
+	require.NoError(t, err)
+	_, resp, err := c.Backups.ListPlans(t.Context(), nil)
+	require.NoError(t, err)
+
+	assert.False(t, resp.Cached)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheSkipsUncachedOperations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithCache(nil))
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	for range 2 {
+		_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+		require.NoError(t, err)
+		assert.False(t, resp.Cached)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestWithCacheValidation(t *testing.T) {
+	cases := map[string]map[string]time.Duration{
+		"mutation":     {"Servers.Create": time.Minute},
+		"action":       {"Servers.Reboot": time.Minute},
+		"unknown":      {"Plans.Unknown": time.Minute},
+		"non-positive": {"Plans.List": 0},
+	}
+
+	for name, ttls := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(WithCache(ttls))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewClient(WithCache(map[string]time.Duration{"Plans.GetBySlug": time.Minute}))
+	assert.NoError(t, err, "Operations that share a read route should be cacheable.")
+}
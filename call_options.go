@@ -0,0 +1,147 @@
+package cherrygo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader is the header that carries the request ID set with [RequestID].
+const RequestIDHeader = "X-Request-ID"
+
+// CallOption configures a single API call. Every service method accepts
+// call options as its trailing arguments, e.g.
+//
+//	srv, _, err := c.Servers.Get(ctx, id, nil, cherrygo.Timeout(5*time.Second), cherrygo.NoRetries())
+//
+// Options of iterating methods, e.g. [ServersClient.All], apply to every
+// page request, and options of [ServersClient.WaitForStatus] to every poll.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout        time.Duration
+	header         http.Header
+	fields         []string
+	noRetries      bool
+	dryRun         bool
+	idempotencyKey string
+	filter         ListFilter
+}
+
+// Timeout limits the time a call may take, including retries and
+// reading the response, on top of any deadline of the call context.
+func Timeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// Header adds a header to the request.
+// The Authorization and Content-Type headers can't be overridden.
+func Header(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// RequestID sets the X-Request-ID header of the request, so it can be
+// correlated with logs of the caller.
+func RequestID(id string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(RequestIDHeader, id)
+	}
+}
+
+// Fields selects the fields the API returns, replacing those of [GetOptions].
+// Fields that aren't selected are left zero in the response. Unknown fields
+// aren't reported, so prefer [FieldSelector.Option], which checks them.
+func Fields(fields ...string) CallOption {
+	return func(o *callOptions) {
+		o.fields = fields
+	}
+}
+
+// NoRetries makes the call be attempted only once, like [ContextWithoutRetries].
+func NoRetries() CallOption {
+	return func(o *callOptions) {
+		o.noRetries = true
+	}
+}
+
+// DryRun makes a mutating call build its request without sending it.
+// The call returns a response with [Meta.DryRun] set and leaves its result zero.
+// Calls that only read, i.e. GET requests, are sent as usual.
+// See [WithDryRun] for dry running every call of a client.
+func DryRun() CallOption {
+	return func(o *callOptions) {
+		o.dryRun = true
+	}
+}
+
+// IdempotencyKey sets the idempotency key of a mutating call,
+// like [ContextWithIdempotencyKey].
+func IdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+type callOptionsCtxKey struct{}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	co := &callOptions{}
+	for _, opt := range opts {
+		opt(co)
+	}
+	return co
+}
+
+// context returns a copy of ctx that carries the call options to the
+// request pipeline and [Client.Do].
+func (co *callOptions) context(ctx context.Context) context.Context {
+	if co.noRetries {
+		ctx = ContextWithoutRetries(ctx)
+	}
+	if co.idempotencyKey != "" {
+		ctx = ContextWithIdempotencyKey(ctx, co.idempotencyKey)
+	}
+	return context.WithValue(ctx, callOptionsCtxKey{}, co)
+}
+
+func callOptionsFromContext(ctx context.Context) *callOptions {
+	if co, ok := ctx.Value(callOptionsCtxKey{}).(*callOptions); ok {
+		return co
+	}
+	return &callOptions{}
+}
+
+// apply sets the headers and query parameters of the call options on req.
+func (co *callOptions) apply(req *http.Request) {
+	for key, values := range co.header {
+		if req.Header.Get(key) != "" && isReservedHeader(key) {
+			continue
+		}
+		req.Header[http.CanonicalHeaderKey(key)] = values
+	}
+
+	if co.fields != nil {
+		q := req.URL.Query()
+		q.Set("fields", strings.Join(co.fields, ","))
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+func isReservedHeader(key string) bool {
+	switch http.CanonicalHeaderKey(key) {
+	case "Authorization", "Content-Type":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package cherrygo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallOptionHeaders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "req-1", r.Header.Get("X-Request-ID"))
+		assert.Equal(t, []string{"a", "b"}, r.Header.Values("X-Extra"))
+		assert.Equal(t, "Bearer "+apiKey, r.Header.Get("Authorization"))
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err := testClient.Servers.Get(t.Context(), 1, nil,
+		RequestID("req-1"),
+		Header("X-Extra", "a"),
+		Header("X-Extra", "b"),
+		Header("Authorization", "Bearer stolen"),
+	)
+	require.NoError(t, err)
+}
+
+func TestCallOptionFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "hostname,status", r.URL.Query().Get("fields"))
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+		_, err := fmt.Fprint(w, `{"hostname": "web", "status": "deployed"}`)
+		require.NoError(t, err)
+	})
+
+	srv, _, err := testClient.Servers.Get(t.Context(), 1, &GetOptions{Fields: []string{"id"}, Limit: 1}, Fields("hostname", "status"))
+	require.NoError(t, err)
+	assert.Equal(t, "web", srv.Hostname)
+}
+
+func TestCallOptionTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers/1", func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	_, _, err := testClient.Servers.Get(t.Context(), 1, nil, Timeout(10*time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCallOptionNoRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, resp, err := testClient.Servers.Get(t.Context(), 1, nil, NoRetries())
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCallOptionIdempotencyKey(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "order-1", r.Header.Get("Idempotency-Key"))
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err := testClient.Servers.Create(t.Context(), &CreateServer{ProjectID: 1}, IdempotencyKey("order-1"))
+	require.NoError(t, err)
+}
+
+func TestCallOptionDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("DELETE /v1/servers/1", func(http.ResponseWriter, *http.Request) {
+		t.Error("dry run request was sent")
+	})
+	mux.HandleFunc("POST /v1/servers/1/actions", func(http.ResponseWriter, *http.Request) {
+		t.Error("dry run request was sent")
+	})
+	mux.HandleFunc("GET /v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	resp, err := testClient.Servers.Delete(t.Context(), 1, DryRun())
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	srv, resp, err := testClient.Servers.Reboot(t.Context(), 1, DryRun())
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Zero(t, srv)
+
+	srv, resp, err = testClient.Servers.Get(t.Context(), 1, nil, DryRun())
+	require.NoError(t, err)
+	assert.False(t, resp.DryRun)
+	assert.Equal(t, 1, srv.ID)
+}
+
+func TestCallOptionsAppliedToPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "req-1", r.Header.Get(RequestIDHeader))
+		w.Header().Set("X-Total-Count", "2")
+		_, err := fmt.Fprintf(w, `[{"id": %s}]`, r.URL.Query().Get("offset")+"1")
+		require.NoError(t, err)
+	})
+
+	ids := []int{}
+	for srv, err := range testClient.Servers.All(t.Context(), 1, &GetOptions{Limit: 1}, RequestID("req-1")) {
+		require.NoError(t, err)
+		ids = append(ids, srv.ID)
+	}
+	assert.Len(t, ids, 2)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,190 @@
+// Package cassette records HTTP interactions with the Cherry Servers API
+// to a file and replays them, so tests can run deterministically without
+// reaching the API.
+//
+// Record a cassette once against the live API:
+//
+//	rec := cassette.NewRecorder(http.DefaultTransport)
+//	c, err := cherrygo.NewClient(cherrygo.WithHTTPClient(&http.Client{Transport: rec}))
+//	// ... make requests ...
+//	err = rec.Save("testdata/servers.json")
+//
+// Then replay it in tests:
+//
+//	cas, err := cassette.Load("testdata/servers.json")
+//	rep := cassette.NewReplayer(cas)
+//	c, err := cherrygo.NewClient(cherrygo.WithAPIKey("fake"), cherrygo.WithHTTPClient(&http.Client{Transport: rep}))
+//
+// Secrets are scrubbed before interactions are stored: the Authorization
+// header, and passwords, private SSH keys and user data in JSON bodies.
+// Use [WithScrubber] to scrub anything else.
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cassette is a sequence of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette from a file.
+func Load(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to a file, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Option is a recorder and replayer configuration option.
+type Option func(*config)
+
+type config struct {
+	matchers  []Matcher
+	scrubbers []Scrubber
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		matchers:  []Matcher{MatchMethod, MatchPath, MatchQuery},
+		scrubbers: []Scrubber{scrubSecrets},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithMatchers sets the matchers that a recorded request must satisfy
+// to be replayed for a request. Defaults to [MatchMethod], [MatchPath] and [MatchQuery].
+func WithMatchers(m ...Matcher) Option {
+	return func(c *config) {
+		c.matchers = m
+	}
+}
+
+// WithScrubber adds a function that scrubs interactions, after the default
+// secret scrubbing. The replayer applies it to incoming requests before matching,
+// so scrubbed values still match.
+func WithScrubber(s Scrubber) Option {
+	return func(c *config) {
+		c.scrubbers = append(c.scrubbers, s)
+	}
+}
+
+// Recorder is an [http.RoundTripper] that records interactions
+// performed by another round tripper. Safe for concurrent use.
+type Recorder struct {
+	transport http.RoundTripper
+	cfg       *config
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder creates a recorder that performs requests with transport.
+func NewRecorder(transport http.RoundTripper, opts ...Option) *Recorder {
+	return &Recorder{
+		transport: transport,
+		cfg:       newConfig(opts),
+	}
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, sent, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+	resp.Request = req
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+	r.cfg.scrub(&i)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette returns a copy of the recorded interactions.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the recorded interactions to a file.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
@@ -0,0 +1,225 @@
+package cassette_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cherryservers/cherrygo/v4"
+	"github.com/cherryservers/cherrygo/v4/cassette"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, transport http.RoundTripper, url string) *cherrygo.Client {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("CHERRY_PROFILE", "")
+	t.Setenv("CHERRY_CONFIG", "")
+
+	c, err := cherrygo.NewClient(
+		cherrygo.WithAPIKey("secret-api-key"),
+		cherrygo.WithURL(url),
+		cherrygo.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestRecordReplay(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, err := fmt.Fprint(w, `{"id": 10, "hostname": "test", "status": "pending"}`)
+		require.NoError(t, err)
+	})
+	polls := 0
+	mux.HandleFunc("GET /v1/servers/10", func(w http.ResponseWriter, _ *http.Request) {
+		polls++
+		status := "pending"
+		if polls > 1 {
+			status = "deployed"
+		}
+		_, err := fmt.Fprintf(w, `{"id": 10, "status": %q, "bmc": {"user": "admin", "password": "bmc-secret"}}`, status)
+		require.NoError(t, err)
+	})
+	ts := httptest.NewServer(mux)
+
+	rec := cassette.NewRecorder(http.DefaultTransport)
+	c := newClient(t, rec, ts.URL)
+
+	_, _, err := c.Servers.Create(t.Context(), &cherrygo.CreateServer{
+		ProjectID: 1,
+		Hostname:  "test",
+		UserData:  "user-data-secret",
+	})
+	require.NoError(t, err)
+	for range 2 {
+		_, _, err = c.Servers.Get(t.Context(), 10, nil)
+		require.NoError(t, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "servers.json")
+	require.NoError(t, rec.Save(path))
+	ts.Close()
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"secret-api-key", "bmc-secret", "user-data-secret"} {
+		assert.NotContains(t, string(raw), secret)
+	}
+
+	cas, err := cassette.Load(path)
+	require.NoError(t, err)
+	require.Len(t, cas.Interactions, 3)
+
+	rep := cassette.NewReplayer(cas, cassette.WithMatchers(
+		cassette.MatchMethod, cassette.MatchPath, cassette.MatchQuery, cassette.MatchBody,
+	))
+	c = newClient(t, rep, "https://api.example.com/v1/")
+
+	srv, _, err := c.Servers.Create(t.Context(), &cherrygo.CreateServer{
+		ProjectID: 1,
+		Hostname:  "test",
+		UserData:  "another-secret",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, srv.ID)
+
+	srv, _, err = c.Servers.Get(t.Context(), 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", srv.Status)
+
+	srv, _, err = c.Servers.Get(t.Context(), 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "deployed", srv.Status)
+
+	_, _, err = c.Servers.Get(t.Context(), 10, nil)
+	assert.ErrorIs(t, err, cassette.ErrNoInteraction)
+	assert.Empty(t, rep.Unused())
+}
+
+func TestReplayMatchers(t *testing.T) {
+	cas := &cassette.Cassette{Interactions: []cassette.Interaction{
+		{
+			Request:  cassette.Request{Method: "GET", URL: "https://api.cherryservers.com/v1/regions?limit=10&offset=0"},
+			Response: cassette.Response{StatusCode: 200, Body: `[{"id": 1}]`},
+		},
+		{
+			Request:  cassette.Request{Method: "POST", URL: "https://api.cherryservers.com/v1/ssh-keys", Body: `{"label":"a","key":"k"}`},
+			Response: cassette.Response{StatusCode: 201, Body: `{"id": 2}`},
+		},
+	}}
+
+	cases := []struct {
+		name     string
+		matchers []cassette.Matcher
+		method   string
+		url      string
+		body     string
+		wantErr  bool
+	}{
+		{"default", nil, "GET", "http://localhost/v1/regions?offset=0&limit=10", "", false},
+		{"query mismatch", nil, "GET", "http://localhost/v1/regions?limit=20&offset=0", "", true},
+		{"path only", []cassette.Matcher{cassette.MatchPath}, "GET", "http://localhost/v1/regions", "", false},
+		{"body match", []cassette.Matcher{cassette.MatchBody}, "POST", "http://localhost/v1/ssh-keys", `{"key": "k", "label": "a"}`, false},
+		{"body mismatch", []cassette.Matcher{cassette.MatchBody}, "POST", "http://localhost/v1/ssh-keys", `{"key": "k", "label": "b"}`, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []cassette.Option
+			if tc.matchers != nil {
+				opts = append(opts, cassette.WithMatchers(tc.matchers...))
+			}
+			rep := cassette.NewReplayer(cas, opts...)
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req, err := http.NewRequest(tc.method, tc.url, body)
+			require.NoError(t, err)
+
+			resp, err := rep.RoundTrip(req)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, cassette.ErrNoInteraction)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, req, resp.Request)
+		})
+	}
+}
+
+func TestWithScrubber(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Account", "12345")
+		_, err := fmt.Fprint(w, `{}`)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	rec := cassette.NewRecorder(http.DefaultTransport, cassette.WithScrubber(func(i *cassette.Interaction) {
+		i.Response.Header.Del("X-Account")
+	}))
+
+	resp, err := (&http.Client{Transport: rec}).Get(ts.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "12345", resp.Header.Get("X-Account"))
+	require.Len(t, rec.Cassette().Interactions, 1)
+	assert.Empty(t, rec.Cassette().Interactions[0].Response.Header.Get("X-Account"))
+}
+
+// onceReader is a request body that can't be read again with GetBody.
+type onceReader struct {
+	io.Reader
+}
+
+func TestRoundTripKeepsRequest(t *testing.T) {
+	var received []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = append(received, string(b))
+		_, err = fmt.Fprint(w, `{}`)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	rec := cassette.NewRecorder(http.DefaultTransport)
+	for _, body := range []io.Reader{strings.NewReader(`{"a": 1}`), onceReader{strings.NewReader(`{"b": 2}`)}} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL, body)
+		require.NoError(t, err)
+		reqBody := req.Body
+
+		resp, err := rec.RoundTrip(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Same(t, req, resp.Request)
+		assert.Equal(t, reqBody, req.Body, "The request body should not be replaced.")
+	}
+	assert.Equal(t, []string{`{"a": 1}`, `{"b": 2}`}, received)
+
+	interactions := rec.Cassette().Interactions
+	require.Len(t, interactions, 2)
+	assert.Equal(t, `{"a": 1}`, interactions[0].Request.Body)
+	assert.Equal(t, `{"b": 2}`, interactions[1].Request.Body)
+
+	rep := cassette.NewReplayer(rec.Cassette(), cassette.WithMatchers(cassette.MatchBody))
+	req, err := http.NewRequest(http.MethodPost, ts.URL, onceReader{strings.NewReader(`{"b": 2}`)})
+	require.NoError(t, err)
+	reqBody := req.Body
+
+	resp, err := rep.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, reqBody, req.Body, "The request body should not be replaced.")
+}
@@ -0,0 +1,77 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+
+	"github.com/cherryservers/cherrygo/v4/internal/redact"
+)
+
+// Matcher reports whether a recorded request matches an incoming request.
+// The incoming request is scrubbed before it is matched.
+type Matcher func(req, recorded Request) bool
+
+// MatchMethod matches requests with the same method.
+func MatchMethod(req, recorded Request) bool {
+	return req.Method == recorded.Method
+}
+
+// MatchPath matches requests with the same URL path, ignoring the host.
+func MatchPath(req, recorded Request) bool {
+	u1, err1 := url.Parse(req.URL)
+	u2, err2 := url.Parse(recorded.URL)
+	return err1 == nil && err2 == nil && u1.Path == u2.Path
+}
+
+// MatchQuery matches requests with the same query parameters, in any order.
+func MatchQuery(req, recorded Request) bool {
+	u1, err1 := url.Parse(req.URL)
+	u2, err2 := url.Parse(recorded.URL)
+	return err1 == nil && err2 == nil && u1.Query().Encode() == u2.Query().Encode()
+}
+
+// MatchBody matches requests with equal bodies. JSON bodies are compared
+// semantically, ignoring formatting and key order.
+func MatchBody(req, recorded Request) bool {
+	if req.Body == recorded.Body {
+		return true
+	}
+
+	var v1, v2 any
+	if json.Unmarshal([]byte(req.Body), &v1) != nil || json.Unmarshal([]byte(recorded.Body), &v2) != nil {
+		return false
+	}
+
+	b1, _ := json.Marshal(v1)
+	b2, _ := json.Marshal(v2)
+	return bytes.Equal(b1, b2)
+}
+
+func (c *config) match(req, recorded Request) bool {
+	for _, m := range c.matchers {
+		if !m(req, recorded) {
+			return false
+		}
+	}
+	return true
+}
+
+// Scrubber removes secrets from an interaction in place.
+type Scrubber func(*Interaction)
+
+// scrubSecrets masks the Authorization header, and passwords,
+// private SSH keys and user data in JSON bodies.
+func scrubSecrets(i *Interaction) {
+	if i.Request.Header != nil {
+		i.Request.Header = redact.Header(i.Request.Header)
+	}
+	i.Request.Body = string(redact.JSON([]byte(i.Request.Body)))
+	i.Response.Body = string(redact.JSON([]byte(i.Response.Body)))
+}
+
+func (c *config) scrub(i *Interaction) {
+	for _, s := range c.scrubbers {
+		s(i)
+	}
+}
@@ -0,0 +1,149 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// ErrNoInteraction is returned by [Replayer] when no unused recorded interaction
+// matches a request.
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// Replayer is an [http.RoundTripper] that serves recorded interactions
+// instead of performing requests. Every interaction is served once,
+// in recorded order, so repeated requests, e.g. when polling, get
+// the responses in the order they were recorded. Safe for concurrent use.
+type Replayer struct {
+	cfg *config
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer creates a replayer that serves the interactions of c.
+func NewReplayer(c *Cassette, opts ...Option) *Replayer {
+	return &Replayer{
+		cfg:          newConfig(opts),
+		interactions: c.Interactions,
+		used:         make([]bool, len(c.Interactions)),
+	}
+}
+
+// RoundTrip implements [http.RoundTripper].
+// Returns an error wrapping [ErrNoInteraction] if no interaction matches req.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _, err := readRequestBody(req)
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Scrub the request like recorded requests were scrubbed.
+	scrubbed := Interaction{Request: Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   string(body),
+	}}
+	r.cfg.scrub(&scrubbed)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, recorded := range r.interactions {
+		if r.used[i] || !r.cfg.match(scrubbed.Request, recorded.Request) {
+			continue
+		}
+
+		r.used[i] = true
+		return newResponse(req, recorded.Response), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// Unused returns the interactions that have not been replayed yet.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.interactions[i])
+		}
+	}
+	return unused
+}
+
+func newResponse(req *http.Request, recorded Response) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.StatusCode) + " " + http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads the body of req without modifying req, as round
+// trippers must not. The body is read from GetBody if possible. Otherwise it's
+// consumed, and the returned request is a copy of req with an unread copy of
+// the body, to send in its place.
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		b, err := io.ReadAll(body)
+		_ = body.Close()
+		return b, req, err
+	}
+
+	b, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = io.NopCloser(bytes.NewReader(b))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	return b, clone, nil
+}
+
+// readBody reads the body and replaces it with an unread copy.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
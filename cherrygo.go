@@ -6,9 +6,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -30,16 +31,37 @@ const (
 // Client is a client for the Cherry Servers RESTful API.
 //
 // Retries failed requests when it's safe to do so, e.g. status 429
-// or a network timeout with an idempotent method. Respects `Retry-After` headers
-// with fallback to exponential backoff with jitter.
+// or a network timeout with an idempotent method. Respects `Retry-After` headers,
+// up to [WithMaxRetryAfter], with fallback to exponential backoff with jitter.
+// Retries that would wait past the context deadline are given up right away,
+// see [ErrRetryDeadline].
 type Client struct {
-	client      *client.Client
-	pollBackoff backoff.Func
+	client          *client.Client
+	pollBackoff     backoff.Func
+	idempotencyKeys bool
+	reconcileWindow time.Duration
+	metrics         Metrics
+	logger          *slog.Logger
+	credentials     CredentialsProvider
+	refresher       *credentialsRefresher
+	dryRun          bool
+	plan            *DryRunPlan
+	validate        bool
 
 	BaseURL *url.URL
 
 	UserAgent string
-	APIKey    string
+
+	// APIKey is the API key requests are sent with, unless the client
+	// has a credentials provider.
+	//
+	// Deprecated: changing the key while requests are in flight is racy.
+	// Use [WithCredentialsProvider] to rotate keys.
+	APIKey string
+
+	// Defaults are the team, project and region
+	// used when requests leave them unset.
+	Defaults Defaults
 
 	Teams       TeamsService
 	Plans       PlansService
@@ -63,10 +85,54 @@ type Response struct {
 // Meta is the response metadata.
 type Meta struct {
 	Total int
+
+	// Attempts is the amount of times the request was sent,
+	// including retries.
+	Attempts int
+
+	// Backoff is the total time spent waiting between attempts.
+	Backoff time.Duration
+
+	// Cached reports whether the response was served from the response cache,
+	// either because it was fresh or because the API confirmed it was not modified.
+	// See [WithCache].
+	Cached bool
+
+	// Coalesced reports whether the response was shared with an identical
+	// request that was already in flight. See [WithCoalescing].
+	Coalesced bool
+
+	// DryRun reports whether the request was not sent, see [DryRun].
+	DryRun bool
+
+	// RequestID identifies the request in the API logs, for support tickets.
+	// It's the ID the API reported, or else the one sent with [RequestID].
+	RequestID string
+
+	// RateLimit is the rate limit budget the API reported, or nil if it didn't
+	// report both the limit and the remaining amount.
+	RateLimit *RateLimit
+
+	// NextPage and PrevPage are the URLs of the adjacent pages from the
+	// Link header, or empty if there are none.
+	NextPage string
+	PrevPage string
+
+	// Date is the time the API sent the response, or zero if unknown.
+	Date time.Time
+
+	// Retried reports whether the request was sent more than once.
+	Retried bool
 }
 
-// NewRequest creates a request. Adds the required headers.
-func (c *Client) NewRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+// NewRequest creates a request. Adds the required headers and applies opts.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body any, opts ...CallOption) (*http.Request, error) {
+	co := newCallOptions(opts)
+	if co.filter != nil {
+		return nil, fmt.Errorf("%T is only supported by List and All methods of the resources it filters", co.filter)
+	}
+	ctx = co.context(ctx)
+
 	url, _ := url.Parse(path)
 	u := c.BaseURL.ResolveReference(url)
 
@@ -75,7 +141,7 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 		coder := json.NewEncoder(buf)
 		err := coder.Encode(body)
 		if err != nil {
-			log.Printf("Error while encoding body: %v -> %v", err, err.Error())
+			c.logger.DebugContext(ctx, "failed to encode request body", "error", err)
 			return nil, err
 		}
 	}
@@ -85,13 +151,20 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 		return nil, err
 	}
 
-	bearer := "Bearer " + c.APIKey
-	req.Header.Set("Authorization", bearer)
+	key, err := c.apiKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
 	req.Header.Set("User-Agent", c.UserAgent)
 	req.Header.Set("Accept", mediaType)
 	if body != nil {
 		req.Header.Add("Content-Type", mediaType)
 	}
+	co.apply(req)
+	if err := c.setIdempotencyKey(req); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -99,10 +172,33 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body any)
 //
 // The response body is un-marshalled into v and closed. v must be a pointer
 // to a type that can hold the expected response, [io.Writer] or nil.
+// Responses with a non-2xx status code are returned along with an [*APIError].
 func (c *Client) Do(req *http.Request, v any) (*Response, error) {
+	co := callOptionsFromContext(req.Context())
+	if c.isDryRun(req, co) {
+		c.skipRequest(req)
+		return dryRunResponse(req), nil
+	}
+	if co.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), co.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	stats := &client.Stats{}
+	req = req.WithContext(client.ContextWithStats(req.Context(), stats))
+
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		var retryErr *client.RetryError
+		if !errors.As(err, &retryErr) || retryErr.Response == nil {
+			return nil, err
+		}
+
+		response := Response{Response: retryErr.Response}
+		response.populateMeta(stats)
+		bod, _ := io.ReadAll(retryErr.Response.Body)
+		return &response, &retryAbortError{retryErr: retryErr, apiErr: newAPIError(req, &response, bod)}
 	}
 
 	defer func() {
@@ -110,29 +206,15 @@ func (c *Client) Do(req *http.Request, v any) (*Response, error) {
 	}()
 
 	response := Response{Response: resp}
-	response.populateTotal()
-
-	if sc := response.StatusCode; sc >= 299 {
-		type ErrorResponse struct {
-			Response *http.Response
-			Code     int    `json:"code"`
-			Message  string `json:"message"`
-		}
-
-		var errorResponse ErrorResponse
+	response.populateMeta(stats)
 
+	if sc := response.StatusCode; sc < 200 || sc >= 300 {
 		bod, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return nil, err
 		}
-		err = json.Unmarshal(bod, &errorResponse)
-		if err != nil {
-			return nil, err
-		}
-
-		err = fmt.Errorf("error response from API: %v (error code: %v)", errorResponse.Message, errorResponse.Code)
 
-		return &response, err
+		return &response, newAPIError(req, &response, bod)
 	}
 
 	// Handling delete requests which EOF is not an error
@@ -152,7 +234,7 @@ func (c *Client) Do(req *http.Request, v any) (*Response, error) {
 			decoder := json.NewDecoder(resp.Body)
 			err := decoder.Decode(&v)
 			if err != nil {
-				log.Printf("error while decoding body: %v -> %v", err, err.Error())
+				c.logger.DebugContext(req.Context(), "failed to decode response body", "error", err)
 				return &response, err
 			}
 		}
@@ -161,25 +243,60 @@ func (c *Client) Do(req *http.Request, v any) (*Response, error) {
 	return &response, nil
 }
 
+// dryRunResponse returns the response of a request that was not sent.
+func dryRunResponse(req *http.Request) *Response {
+	status := http.StatusOK
+	if req.Method == http.MethodDelete {
+		status = http.StatusNoContent
+	}
+
+	return &Response{
+		Response: &http.Response{
+			Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       http.NoBody,
+			Request:    req,
+		},
+		Meta: Meta{DryRun: true, RequestID: req.Header.Get(RequestIDHeader)},
+	}
+}
+
 type options struct {
-	url         string
-	client      *http.Client
-	userAgent   string
-	apiKey      string
-	debugDst    io.Writer
-	pollBackoff backoff.Func
+	url             string
+	client          *http.Client
+	userAgent       string
+	apiKey          string
+	debugDst        io.Writer
+	pollBackoff     backoff.Func
+	idempotencyKeys bool
+	reconcileWindow time.Duration
+	metrics         Metrics
+	logger          *slog.Logger
+	profile         string
+	configPath      string
+	defaults        Defaults
+	credentials     CredentialsProvider
+	dryRun          bool
+	plan            *DryRunPlan
+	validate        bool
+	clientOpts      []client.Option
 }
 
 // ClientOpt is a client configuration option.
 type ClientOpt func(*options) error
 
 // NewClient creates a Cherry Servers API client.
+//
+// Settings that are not set with options are read from the environment
+// and then from a configuration file profile, see [WithProfile].
 func NewClient(opts ...ClientOpt) (*Client, error) {
 	parsedOpts := &options{
-		apiKey:    os.Getenv(cherryAPIKeyVar),
-		client:    &http.Client{},
-		url:       apiURL,
-		userAgent: userAgent,
+		client: &http.Client{},
+		logger: discardLogger,
 		pollBackoff: backoff.ExponentialBackoff(
 			backoff.ExponentialBackoffConfig{
 				Base:       1 * time.Second,
@@ -193,7 +310,10 @@ func NewClient(opts ...ClientOpt) (*Client, error) {
 			return nil, err
 		}
 	}
-	if parsedOpts.apiKey == "" {
+	if err := parsedOpts.resolve(); err != nil {
+		return nil, err
+	}
+	if parsedOpts.apiKey == "" && parsedOpts.credentials == nil {
 		return nil, fmt.Errorf("api key must be provided as an option or environment variable %s", cherryAPIKeyVar)
 	}
 
@@ -207,13 +327,32 @@ func NewClient(opts ...ClientOpt) (*Client, error) {
 	}
 
 	c := &Client{
-		client:      client.New(client.WithHTTPClient(parsedOpts.client), client.WithDebug(parsedOpts.debugDst)),
-		APIKey:      parsedOpts.apiKey,
-		BaseURL:     url,
-		UserAgent:   parsedOpts.userAgent,
-		pollBackoff: parsedOpts.pollBackoff,
+		APIKey:          parsedOpts.apiKey,
+		BaseURL:         url,
+		UserAgent:       parsedOpts.userAgent,
+		Defaults:        parsedOpts.defaults,
+		pollBackoff:     parsedOpts.pollBackoff,
+		idempotencyKeys: parsedOpts.idempotencyKeys,
+		reconcileWindow: parsedOpts.reconcileWindow,
+		metrics:         parsedOpts.metrics,
+		logger:          parsedOpts.logger,
+		credentials:     parsedOpts.credentials,
+		dryRun:          parsedOpts.dryRun,
+		plan:            parsedOpts.plan,
+		validate:        parsedOpts.validate,
 	}
 
+	clientOpts := append(parsedOpts.clientOpts,
+		client.WithHTTPClient(parsedOpts.client),
+		client.WithDebug(parsedOpts.debugDst),
+	)
+	if c.credentials != nil {
+		c.refresher = &credentialsRefresher{provider: c.credentials}
+		// Innermost call middleware, so the others see a single call.
+		clientOpts = append(clientOpts, client.WithCallMiddleware(c.refreshCredentials))
+	}
+	c.client = client.New(clientOpts...)
+
 	c.Teams = &TeamsClient{client: c}
 	c.Plans = &PlansClient{client: c}
 	c.Images = &ImagesClient{client: c}
@@ -261,7 +400,7 @@ func WithHTTPClient(client *http.Client) ClientOpt {
 }
 
 // WithAPIKey is used to provide a Cherry Servers API key to make requests, defaults to environment variable
-// CHERRY_API_KEY
+// CHERRY_API_KEY, or else the API key of the profile
 func WithAPIKey(apiKey string) ClientOpt {
 	return func(c *options) error {
 		c.apiKey = apiKey
@@ -272,6 +411,8 @@ func WithAPIKey(apiKey string) ClientOpt {
 // WithDebug enables debug mode, which dumps logs to w.
 // Can also be enabled with the CHERRY_DEBUG environment variable, in which case logs
 // will be dumped to stderr.
+//
+// The API key, passwords, private SSH keys and user data are redacted from the dumps.
 func WithDebug(w io.Writer) ClientOpt {
 	return func(c *options) error {
 		c.debugDst = w
@@ -280,6 +421,8 @@ func WithDebug(w io.Writer) ClientOpt {
 }
 
 // WithPollBackoff sets a custom backoff function for polling.
+// Polling gives up with an error once it returns [backoff.Stop],
+// e.g. when wrapped with [backoff.MaxAttempts].
 func WithPollBackoff(b backoff.Func) ClientOpt {
 	return func(c *options) error {
 		c.pollBackoff = b
@@ -287,9 +430,81 @@ func WithPollBackoff(b backoff.Func) ClientOpt {
 	}
 }
 
-func (r *Response) populateTotal() {
+// WithRateLimit limits requests to rate per second, allowing bursts of up to burst requests.
+// Callers wait for their turn, until their request context is done.
+//
+// The limit is applied to every request attempt, so retries count towards it.
+// When responses report that the API rate limit is exhausted, requests are
+// paused until it resets.
+func WithRateLimit(rate float64, burst int) ClientOpt {
+	return func(c *options) error {
+		if rate <= 0 || burst <= 0 {
+			return fmt.Errorf("rate limit and burst must be positive, got %v and %d", rate, burst)
+		}
+		c.clientOpts = append(c.clientOpts, client.WithRateLimit(rate, burst))
+		return nil
+	}
+}
+
+// WithMaxInFlight limits the amount of request attempts in flight at once to n.
+// Callers wait for a free slot, until their request context is done.
+func WithMaxInFlight(n int) ClientOpt {
+	return func(c *options) error {
+		if n <= 0 {
+			return fmt.Errorf("max in flight must be positive, got %d", n)
+		}
+		c.clientOpts = append(c.clientOpts, client.WithMaxInFlight(n))
+		return nil
+	}
+}
+
+// WithIdempotencyKeys makes the client attach a generated idempotency key
+// to every POST and PATCH request, which makes them safe to retry.
+// Keys set with [ContextWithIdempotencyKey] take precedence.
+func WithIdempotencyKeys() ClientOpt {
+	return func(c *options) error {
+		c.idempotencyKeys = true
+		return nil
+	}
+}
+
+// WithCreateReconciliation enables reconciliation of server orders that
+// fail ambiguously, e.g. with a network timeout, which leaves it unknown
+// whether the server was ordered.
+//
+// Before trying again, [ServersClient.Create] looks for a project server
+// with the requested hostname and tags, that was created no earlier than
+// window ago, and returns it if found. Requests without a hostname or
+// tags can't be reconciled and are not tried again.
+func WithCreateReconciliation(window time.Duration) ClientOpt {
+	return func(c *options) error {
+		if window <= 0 {
+			return fmt.Errorf("reconciliation window must be positive, got %v", window)
+		}
+		c.reconcileWindow = window
+		return nil
+	}
+}
+
+func (r *Response) populateMeta(stats *client.Stats) {
 	// parse the headers and populate Meta.Total
 	if total := r.Header.Get("X-Total-Count"); total != "" {
 		r.Total, _ = strconv.Atoi(total)
 	}
+
+	r.RequestID = r.Header.Get(RequestIDHeader)
+	if r.RequestID == "" && r.Request != nil {
+		r.RequestID = r.Request.Header.Get(RequestIDHeader)
+	}
+	r.RateLimit = parseRateLimit(r.Header, time.Now())
+	r.NextPage, r.PrevPage = parseLinks(r.Header)
+	if date, err := http.ParseTime(r.Header.Get("Date")); err == nil {
+		r.Date = date
+	}
+
+	r.Attempts = stats.Attempts
+	r.Backoff = stats.Backoff
+	r.Cached = stats.Cached
+	r.Coalesced = stats.Coalesced
+	r.Retried = stats.Attempts > 1
 }
@@ -2,6 +2,7 @@ package cherrygo
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +26,21 @@ var (
 
 var apiKey = "myKey"
 
+// TestMain keeps the tests from reading the configuration of the developer.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "cherrygo")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("XDG_CONFIG_HOME", dir)
+	_ = os.Unsetenv(cherryProfileVar)
+	_ = os.Unsetenv(cherryConfigVar)
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func setup() {
 	_ = os.Setenv("CHERRY_API_KEY", apiKey)
 
@@ -138,3 +155,30 @@ func TestDebug(t *testing.T) {
 	assert.Contains(t, string(got), "RESPONSE")
 	assert.NotContains(t, string(got), "HIDDEN")
 }
+
+func TestRateLimitOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/regions", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `[]`)
+		require.NoError(t, err)
+	})
+
+	c, err := NewClient(WithURL(server.URL), WithRateLimit(1, 1), WithMaxInFlight(1))
+	require.NoError(t, err)
+
+	_, _, err = c.Regions.List(t.Context(), nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, _, err = c.Regions.List(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = NewClient(WithAPIKey("key"), WithRateLimit(0, 1))
+	assert.Error(t, err)
+	_, err = NewClient(WithAPIKey("key"), WithMaxInFlight(0))
+	assert.Error(t, err)
+}
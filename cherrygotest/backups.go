@@ -0,0 +1,213 @@
+package cherrygotest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerBackups(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/backup-storage-plans", s.listBackupPlans)
+	s.handle(mux, "GET /v1/projects/{project_id}/backup-storages", s.listBackups)
+	s.handle(mux, "POST /v1/servers/{server_id}/backup-storages", s.createBackup)
+	s.handle(mux, "GET /v1/backup-storages/{backup_id}", s.getBackup)
+	s.handle(mux, "PUT /v1/backup-storages/{backup_id}", s.updateBackup)
+	s.handle(mux, "DELETE /v1/backup-storages/{backup_id}", s.deleteBackup)
+	s.handle(mux, "PATCH /v1/backup-storages/{backup_id}/methods/{method}", s.updateBackupMethod)
+}
+
+func (s *Server) renderBackup(rec *backupRecord) cherrygo.BackupStorage {
+	b := rec.backup
+	b.Status = s.status(rec.ordered, false, "deployed")
+	b.State = "pending"
+	if b.Status == "deployed" {
+		b.State = "active"
+	}
+	b.Methods = slices.Clone(b.Methods)
+	return b
+}
+
+func (s *Server) findBackup(w http.ResponseWriter, r *http.Request) (*backupRecord, bool) {
+	id, ok := pathID(w, r, "backup_id")
+	if !ok {
+		return nil, false
+	}
+
+	b, ok := s.state.backups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "backup storage %d not found", id)
+		return nil, false
+	}
+	return b, true
+}
+
+func (s *Server) backupPlan(slug string) (cherrygo.BackupStoragePlan, bool) {
+	for _, p := range s.state.backupPlans {
+		if p.Slug == slug {
+			return p, true
+		}
+	}
+	return cherrygo.BackupStoragePlan{}, false
+}
+
+func (s *Server) listBackupPlans(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, s.state.backupPlans)
+}
+
+func (s *Server) listBackups(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var backups []cherrygo.BackupStorage
+	for _, b := range sortedBy(s.state.backups, func(b *backupRecord) int { return b.backup.ID }) {
+		if b.projectID == p.project.ID {
+			backups = append(backups, s.renderBackup(b))
+		}
+	}
+	writePage(w, r, backups)
+}
+
+func (s *Server) createBackup(w http.ResponseWriter, r *http.Request) {
+	srv, ok := s.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.CreateBackup
+	if !decode(w, r, &req) {
+		return
+	}
+
+	plan, ok := s.backupPlan(req.BackupPlanSlug)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "backup storage plan %q not found", req.BackupPlanSlug)
+		return
+	}
+	region, ok := s.state.region(req.RegionSlug)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "region %q not found", req.RegionSlug)
+		return
+	}
+	if srv.backupID != 0 {
+		writeError(w, http.StatusConflict, "server %d already has a backup storage", srv.server.ID)
+		return
+	}
+
+	id := s.state.id()
+	host := fmt.Sprintf("backup-%d.example.com", id)
+	user := fmt.Sprintf("backup%d", id)
+
+	rec := &backupRecord{
+		backup: cherrygo.BackupStorage{
+			ID:            id,
+			PrivateIP:     fmt.Sprintf("10.169.%d.%d", id/256%256, id%256),
+			SizeGigabytes: plan.SizeGigabytes,
+			AttachedTo: cherrygo.AttachedTo{
+				ID:       srv.server.ID,
+				Hostname: srv.server.Hostname,
+				Href:     srv.server.Href,
+			},
+			Methods: []cherrygo.BackupMethod{
+				{Name: "borg", Username: user, Host: host, Port: 22, SSHKey: req.SSHKey},
+				{Name: "ftp", Username: user, Host: host, Port: 21},
+				{Name: "nfs", Host: host},
+				{Name: "smb", Username: user, Host: host, Port: 445},
+			},
+			Plan:   cherrygo.Plan{ID: plan.ID, Name: plan.Name, Slug: plan.Slug},
+			Region: region,
+			Href:   "/backup-storages/" + itoa(id),
+		},
+		projectID: srv.projectID,
+		serverID:  srv.server.ID,
+		ordered:   s.now(),
+	}
+	s.state.backups[id] = rec
+	srv.backupID = id
+
+	writeJSON(w, http.StatusCreated, s.renderBackup(rec))
+}
+
+func (s *Server) getBackup(w http.ResponseWriter, r *http.Request) {
+	if b, ok := s.findBackup(w, r); ok {
+		writeJSON(w, http.StatusOK, s.renderBackup(b))
+	}
+}
+
+func (s *Server) updateBackup(w http.ResponseWriter, r *http.Request) {
+	b, ok := s.findBackup(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateBackupStorage
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.BackupPlanSlug != "" {
+		plan, ok := s.backupPlan(req.BackupPlanSlug)
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "backup storage plan %q not found", req.BackupPlanSlug)
+			return
+		}
+		b.backup.Plan = cherrygo.Plan{ID: plan.ID, Name: plan.Name, Slug: plan.Slug}
+		b.backup.SizeGigabytes = plan.SizeGigabytes
+	}
+	for i := range b.backup.Methods {
+		m := &b.backup.Methods[i]
+		if req.Password != "" && m.Username != "" {
+			m.Password = req.Password
+		}
+		if req.SSHKey != "" && m.Name == "borg" {
+			m.SSHKey = req.SSHKey
+		}
+	}
+
+	writeJSON(w, http.StatusOK, s.renderBackup(b))
+}
+
+func (s *Server) deleteBackup(w http.ResponseWriter, r *http.Request) {
+	b, ok := s.findBackup(w, r)
+	if !ok {
+		return
+	}
+
+	if srv, ok := s.state.servers[b.serverID]; ok {
+		srv.backupID = 0
+	}
+	delete(s.state.backups, b.backup.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) updateBackupMethod(w http.ResponseWriter, r *http.Request) {
+	b, ok := s.findBackup(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.PathValue("method")
+	i := slices.IndexFunc(b.backup.Methods, func(m cherrygo.BackupMethod) bool { return m.Name == name })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "backup method %q not found", name)
+		return
+	}
+
+	var req cherrygo.UpdateBackupMethod
+	if !decode(w, r, &req) {
+		return
+	}
+
+	m := &b.backup.Methods[i]
+	if req.Enabled != nil {
+		m.Enabled = *req.Enabled
+	}
+	if req.Whitelist != nil {
+		m.WhiteList = slices.Clone(*req.Whitelist)
+	}
+
+	writeJSON(w, http.StatusOK, s.renderBackup(b).Methods)
+}
@@ -0,0 +1,83 @@
+package cherrygotest
+
+import (
+	"net/http"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerCatalog(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/plans", s.listPlans)
+	s.handle(mux, "GET /v1/teams/{team_id}/plans", s.listTeamPlans)
+	s.handle(mux, "GET /v1/plans/{plan}", s.getPlan)
+	s.handle(mux, "GET /v1/plans/{plan}/prebuilts", s.listPrebuiltPlans)
+	s.handle(mux, "GET /v1/teams/{team_id}/plans/{plan}/prebuilts", s.listPrebuiltTeamPlans)
+	s.handle(mux, "GET /v1/plans/{plan}/images", s.listImages)
+
+	s.handle(mux, "GET /v1/regions", s.listRegions)
+	s.handle(mux, "GET /v1/regions/{region}", s.getRegion)
+}
+
+func (s *Server) findPlan(w http.ResponseWriter, r *http.Request) (cherrygo.Plan, bool) {
+	p, ok := s.state.plan(r.PathValue("plan"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "plan %q not found", r.PathValue("plan"))
+	}
+	return p, ok
+}
+
+func (s *Server) listPlans(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, s.state.plans)
+}
+
+func (s *Server) listTeamPlans(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.findTeam(w, r); ok {
+		writePage(w, r, s.state.plans)
+	}
+}
+
+func (s *Server) getPlan(w http.ResponseWriter, r *http.Request) {
+	if p, ok := s.findPlan(w, r); ok {
+		writeJSON(w, http.StatusOK, p)
+	}
+}
+
+func (s *Server) listPrebuiltPlans(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findPlan(w, r)
+	if !ok {
+		return
+	}
+	if _, ok := s.state.region(r.URL.Query().Get("region")); !ok {
+		writeError(w, http.StatusUnprocessableEntity, "region %q not found", r.URL.Query().Get("region"))
+		return
+	}
+
+	writePage(w, r, s.state.prebuilts[p.Slug])
+}
+
+func (s *Server) listPrebuiltTeamPlans(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.findTeam(w, r); ok {
+		s.listPrebuiltPlans(w, r)
+	}
+}
+
+func (s *Server) listImages(w http.ResponseWriter, r *http.Request) {
+	if p, ok := s.findPlan(w, r); ok {
+		writePage(w, r, s.state.images[p.Slug])
+	}
+}
+
+func (s *Server) listRegions(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, s.state.regions)
+}
+
+func (s *Server) getRegion(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("region")
+	for _, region := range s.state.regions {
+		if region.Slug == slug || itoa(region.ID) == slug {
+			writeJSON(w, http.StatusOK, region)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "region %q not found", slug)
+}
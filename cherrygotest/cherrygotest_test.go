@@ -0,0 +1,400 @@
+package cherrygotest_test
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4"
+	"github.com/cherryservers/cherrygo/v4/cherrygotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain keeps the clients from reading the configuration of the developer.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "cherrygotest")
+	if err != nil {
+		panic(err)
+	}
+	_ = os.Setenv("XDG_CONFIG_HOME", dir)
+	_ = os.Unsetenv("CHERRY_PROFILE")
+	_ = os.Unsetenv("CHERRY_CONFIG")
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func newProject(t *testing.T, c *cherrygo.Client) cherrygo.Project {
+	t.Helper()
+
+	team, _, err := c.Teams.Create(t.Context(), &cherrygo.CreateTeam{Name: "team", Currency: "EUR"})
+	require.NoError(t, err)
+
+	project, _, err := c.Projects.Create(t.Context(), team.ID, &cherrygo.CreateProject{Name: "project"})
+	require.NoError(t, err)
+	return project
+}
+
+func TestServerLifecycle(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient(cherrygo.WithPollBackoff(func(int, *http.Response) time.Duration {
+		fake.Clock().Advance(time.Minute)
+		return 0
+	}))
+	require.NoError(t, err)
+
+	project := newProject(t, c)
+	key, _, err := c.SSHKeys.Create(t.Context(), &cherrygo.CreateSSHKey{Label: "key", Key: "ssh-ed25519 AAAA"})
+	require.NoError(t, err)
+
+	srv, _, err := c.Servers.Create(t.Context(), &cherrygo.CreateServer{
+		ProjectID: project.ID,
+		Plan:      cherrygotest.DefaultPlan.Slug,
+		Region:    cherrygotest.DefaultRegion.Slug,
+		Image:     cherrygotest.DefaultImage.Slug,
+		Hostname:  "web",
+		SSHKeys:   []string{itoa(key.ID)},
+		Tags:      &map[string]string{"env": "test"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", srv.Status)
+	assert.Equal(t, "web", srv.Hostname)
+	assert.Equal(t, project.ID, srv.Project.ID)
+	require.Len(t, srv.IPAddresses, 1)
+	assert.Equal(t, "primary-ip", srv.IPAddresses[0].Type)
+
+	fake.Clock().Advance(2 * time.Minute)
+	srv, _, err = c.Servers.Get(t.Context(), srv.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "provisioning", srv.Status)
+
+	srv, _, err = c.Servers.WaitForStatus(t.Context(), srv.ID, cherrygo.StatusDeployed)
+	require.NoError(t, err)
+	assert.Equal(t, "deployed", srv.Status)
+	assert.Equal(t, cherrygotest.DefaultImage.Name, srv.DeployedImage.Name)
+
+	keys, _, err := c.Servers.ListSSHKeys(t.Context(), srv.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []cherrygo.SSHKey{key}, keys)
+
+	power, _, err := c.Servers.PowerState(t.Context(), srv.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "on", power.Power)
+
+	_, _, err = c.Servers.PowerOff(t.Context(), srv.ID)
+	require.NoError(t, err)
+	power, _, err = c.Servers.PowerState(t.Context(), srv.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "off", power.Power)
+
+	srv, _, err = c.Servers.Update(t.Context(), srv.ID, &cherrygo.UpdateServer{Tags: &map[string]string{"env": "prod"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, srv.Tags)
+
+	servers, _, err := c.Servers.List(t.Context(), project.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+
+	_, err = c.Servers.Delete(t.Context(), srv.ID)
+	require.NoError(t, err)
+
+	_, _, err = c.Servers.Get(t.Context(), srv.ID, nil)
+	assert.ErrorIs(t, err, cherrygo.ErrNotFound)
+}
+
+func TestFailNextDeployment(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient(cherrygo.WithPollBackoff(func(int, *http.Response) time.Duration {
+		fake.Clock().Advance(time.Minute)
+		return 0
+	}))
+	require.NoError(t, err)
+	project := newProject(t, c)
+
+	fake.FailNextDeployment()
+	srv, _, err := c.Servers.Create(t.Context(), &cherrygo.CreateServer{
+		ProjectID: project.ID,
+		Plan:      cherrygotest.DefaultPlan.Slug,
+		Region:    cherrygotest.DefaultRegion.Slug,
+		Image:     cherrygotest.DefaultImage.Slug,
+	})
+	require.NoError(t, err)
+
+	srv, _, err = c.Servers.WaitForStatus(t.Context(), srv.ID, cherrygo.StatusDeployed)
+	require.Error(t, err)
+	assert.Equal(t, cherrygo.StatusFailed.String(), srv.Status)
+}
+
+func TestCreateServerValidation(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+	project := newProject(t, c)
+
+	_, _, err = c.Servers.Create(t.Context(), &cherrygo.CreateServer{
+		ProjectID: project.ID,
+		Plan:      "unknown",
+		Region:    cherrygotest.DefaultRegion.Slug,
+		Image:     cherrygotest.DefaultImage.Slug,
+	})
+	assert.ErrorIs(t, err, cherrygo.ErrUnprocessableEntity)
+
+	_, _, err = c.Servers.Create(t.Context(), &cherrygo.CreateServer{ProjectID: 404})
+	assert.ErrorIs(t, err, cherrygo.ErrNotFound)
+}
+
+func TestInjectFailure(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient(cherrygo.WithRetryBackoff(func(int, *http.Response) time.Duration { return 0 }))
+	require.NoError(t, err)
+
+	fake.InjectFailure("GET /v1/regions", cherrygotest.Failure{StatusCode: http.StatusServiceUnavailable, Times: 2})
+
+	regions, resp, err := c.Regions.List(t.Context(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.Attempts)
+	assert.Equal(t, []cherrygo.Region{cherrygotest.DefaultRegion}, regions)
+
+	fake.InjectFailure("GET /v1/regions", cherrygotest.Failure{StatusCode: http.StatusTooManyRequests})
+	_, _, err = c.Regions.List(t.Context(), nil)
+	assert.ErrorIs(t, err, cherrygo.ErrRateLimited)
+
+	fake.ClearFailures()
+	_, _, err = c.Regions.List(t.Context(), nil)
+	assert.NoError(t, err)
+
+	assert.Panics(t, func() {
+		fake.InjectFailure("GET /v1/unknown", cherrygotest.Failure{StatusCode: http.StatusInternalServerError})
+	})
+}
+
+func TestInjectFailureRetryAfter(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	retryAfter := func() string {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, fake.URL+"/v1/regions", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer key")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		return resp.Header.Get("Retry-After")
+	}
+
+	fake.InjectFailure("GET /v1/regions", cherrygotest.Failure{StatusCode: http.StatusTooManyRequests})
+	assert.Empty(t, retryAfter())
+
+	delay := 2 * time.Second
+	fake.InjectFailure("GET /v1/regions", cherrygotest.Failure{StatusCode: http.StatusTooManyRequests, RetryAfter: &delay})
+	assert.Equal(t, "2", retryAfter())
+}
+
+func TestUnauthorized(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+	c.APIKey = ""
+
+	_, _, err = c.Users.CurrentUser(t.Context(), nil)
+	assert.ErrorIs(t, err, cherrygo.ErrUnauthorized)
+}
+
+func TestIPAddresses(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+	project := newProject(t, c)
+
+	srv := fake.AddServer(project.ID, cherrygo.Server{Hostname: "db", Region: cherrygotest.DefaultRegion})
+	assert.Equal(t, "deployed", srv.Status)
+
+	ip, _, err := c.IPAddresses.Create(t.Context(), project.ID, &cherrygo.CreateIPAddress{
+		Region:    cherrygotest.DefaultRegion.Slug,
+		PTRRecord: "db.example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "floating-ip", ip.Type)
+	assert.Equal(t, "db.example.com", ip.PTRRecord)
+
+	ip, _, err = c.IPAddresses.Assign(t.Context(), ip.ID, &cherrygo.AssignIPAddress{ServerID: srv.ID})
+	require.NoError(t, err)
+	assert.Equal(t, srv.ID, ip.TargetedTo.ID)
+
+	got, ok := fake.ServerByID(srv.ID)
+	require.True(t, ok)
+	require.Len(t, got.IPAddresses, 1)
+	assert.Equal(t, ip.ID, got.IPAddresses[0].ID)
+
+	_, err = c.IPAddresses.Unassign(t.Context(), ip.ID)
+	require.NoError(t, err)
+	ip, _, err = c.IPAddresses.Get(t.Context(), ip.ID, nil)
+	require.NoError(t, err)
+	assert.Zero(t, ip.TargetedTo.ID)
+
+	ips, _, err := c.IPAddresses.List(t.Context(), project.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, ips, 1)
+
+	_, err = c.IPAddresses.Remove(t.Context(), ip.ID)
+	require.NoError(t, err)
+}
+
+func TestStorages(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+	project := newProject(t, c)
+	srv := fake.AddServer(project.ID, cherrygo.Server{Hostname: "db", Region: cherrygotest.DefaultRegion})
+
+	st, _, err := c.Storages.Create(t.Context(), project.ID, &cherrygo.CreateStorage{
+		Size:   100,
+		Region: cherrygotest.DefaultRegion.Slug,
+	})
+	require.NoError(t, err)
+
+	st, _, err = c.Storages.Attach(t.Context(), st.ID, &cherrygo.AttachTo{AttachTo: srv.ID})
+	require.NoError(t, err)
+	assert.Equal(t, srv.ID, st.AttachedTo.ID)
+
+	_, err = c.Storages.Delete(t.Context(), st.ID)
+	assert.ErrorIs(t, err, cherrygo.ErrConflict)
+
+	_, _, err = c.Storages.Update(t.Context(), st.ID, &cherrygo.UpdateStorage{Size: 50})
+	assert.ErrorIs(t, err, cherrygo.ErrUnprocessableEntity)
+
+	_, err = c.Storages.Detach(t.Context(), st.ID)
+	require.NoError(t, err)
+	_, err = c.Storages.Delete(t.Context(), st.ID)
+	require.NoError(t, err)
+}
+
+func TestBackups(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+	project := newProject(t, c)
+	srv := fake.AddServer(project.ID, cherrygo.Server{Hostname: "db", Region: cherrygotest.DefaultRegion})
+
+	plans, _, err := c.Backups.ListPlans(t.Context(), nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, plans)
+
+	b, _, err := c.Backups.Create(t.Context(), srv.ID, &cherrygo.CreateBackup{
+		BackupPlanSlug: plans[0].Slug,
+		RegionSlug:     cherrygotest.DefaultRegion.Slug,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", b.Status)
+
+	fake.Clock().Advance(time.Hour)
+	b, _, err = c.Backups.Get(t.Context(), b.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "deployed", b.Status)
+
+	enabled := true
+	methods, _, err := c.Backups.UpdateBackupMethod(t.Context(), b.ID, "ftp", &cherrygo.UpdateBackupMethod{
+		Enabled:   &enabled,
+		Whitelist: &[]string{"198.18.0.1"},
+	})
+	require.NoError(t, err)
+	for _, m := range methods {
+		assert.Equal(t, m.Name == "ftp", m.Enabled, m.Name)
+	}
+
+	_, _, err = c.Backups.UpdateBackupMethod(t.Context(), b.ID, "unknown", &cherrygo.UpdateBackupMethod{})
+	assert.ErrorIs(t, err, cherrygo.ErrNotFound)
+
+	backups, _, err := c.Backups.ListBackups(t.Context(), project.ID, nil)
+	require.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestCatalog(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	fake.AddPrebuiltPlans(cherrygotest.DefaultPlan.Slug, cherrygo.PrebuiltPlan{ID: 1, StockQty: 3})
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+
+	plans, _, err := c.Plans.List(t.Context(), 0, nil)
+	require.NoError(t, err)
+	assert.Len(t, plans, 2)
+
+	plan, _, err := c.Plans.GetBySlug(t.Context(), cherrygotest.DefaultPlan.Slug, nil)
+	require.NoError(t, err)
+	assert.Equal(t, cherrygotest.DefaultPlan.ID, plan.ID)
+
+	prebuilts, _, err := c.Plans.ListPrebuiltPlans(t.Context(), plan.Slug, cherrygotest.DefaultRegion.Slug, nil)
+	require.NoError(t, err)
+	assert.Len(t, prebuilts, 1)
+
+	images, _, err := c.Images.List(t.Context(), plan.Slug, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []cherrygo.Image{cherrygotest.DefaultImage}, images)
+
+	region, _, err := c.Regions.Get(t.Context(), cherrygotest.DefaultRegion.Slug, nil)
+	require.NoError(t, err)
+	assert.Equal(t, cherrygotest.DefaultRegion, region)
+
+	cycles, _, err := c.Servers.ListCycles(t.Context(), nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, cycles)
+
+	user, _, err := c.Users.CurrentUser(t.Context(), nil)
+	require.NoError(t, err)
+	assert.NotZero(t, user.ID)
+}
+
+func TestPagination(t *testing.T) {
+	fake := cherrygotest.NewServer()
+	defer fake.Close()
+
+	for i := range 5 {
+		fake.AddSSHKey(cherrygo.SSHKey{Label: "key" + itoa(i)})
+	}
+
+	c, err := fake.NewClient()
+	require.NoError(t, err)
+
+	page, resp, err := c.SSHKeys.List(t.Context(), &cherrygo.GetOptions{Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 5, resp.Total)
+	require.Len(t, page, 2)
+	assert.Equal(t, "key2", page[0].Label)
+
+	var labels []string
+	for key, err := range c.SSHKeys.All(t.Context(), &cherrygo.GetOptions{Limit: 2}) {
+		require.NoError(t, err)
+		labels = append(labels, key.Label)
+	}
+	assert.Equal(t, []string{"key0", "key1", "key2", "key3", "key4"}, labels)
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
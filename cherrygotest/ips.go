@@ -0,0 +1,235 @@
+package cherrygotest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerIPAddresses(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/projects/{project_id}/ips", s.listIPs)
+	s.handle(mux, "POST /v1/projects/{project_id}/ips", s.createIP)
+	s.handle(mux, "GET /v1/ips/{ip_id}", s.getIP)
+	s.handle(mux, "PUT /v1/ips/{ip_id}", s.updateIP)
+	s.handle(mux, "DELETE /v1/ips/{ip_id}", s.removeIP)
+}
+
+// newIP allocates an address from the 198.18.0.0/15 benchmarking range.
+func (s *Server) newIP(projectID int, ipType string, region cherrygo.Region) *ipRecord {
+	s.state.nextIPID++
+	n := s.state.nextIPID
+
+	rec := &ipRecord{
+		ip: cherrygo.IPAddress{
+			ID:            fmt.Sprintf("00000000-0000-4000-8000-%012d", n),
+			Address:       fmt.Sprintf("198.18.%d.%d", n/256, n%256),
+			AddressFamily: 4,
+			Type:          ipType,
+			Region:        region,
+		},
+		seq:       n,
+		projectID: projectID,
+	}
+	rec.ip.CIDR = rec.ip.Address + "/32"
+	rec.ip.Gateway = fmt.Sprintf("198.18.%d.1", n/256)
+	rec.ip.Href = "/ips/" + rec.ip.ID
+
+	s.state.ips[rec.ip.ID] = rec
+	return rec
+}
+
+func (s *Server) renderIP(rec *ipRecord) cherrygo.IPAddress {
+	ip := rec.ip
+	ip.AssignedTo = cherrygo.AssignedTo{}
+	ip.TargetedTo = cherrygo.AssignedTo{}
+
+	if p, ok := s.state.projects[rec.projectID]; ok {
+		ip.Project = p.project
+	}
+
+	if srv, ok := s.state.servers[rec.serverID]; ok {
+		to := cherrygo.AssignedTo{
+			ID:       srv.server.ID,
+			Name:     srv.server.Name,
+			Href:     srv.server.Href,
+			Hostname: srv.server.Hostname,
+			Region:   srv.server.Region,
+		}
+		if ip.Type == "primary-ip" {
+			ip.AssignedTo = to
+		} else {
+			ip.TargetedTo = to
+		}
+	}
+
+	return ip
+}
+
+func (s *Server) ipList() []*ipRecord {
+	return sortedBy(s.state.ips, func(ip *ipRecord) int { return ip.seq })
+}
+
+func (s *Server) findIP(w http.ResponseWriter, r *http.Request) (*ipRecord, bool) {
+	id := r.PathValue("ip_id")
+	ip, ok := s.state.ips[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ip address %q not found", id)
+		return nil, false
+	}
+	return ip, true
+}
+
+func (s *Server) listIPs(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	types := r.URL.Query()["type[]"]
+
+	var ips []cherrygo.IPAddress
+	for _, rec := range s.ipList() {
+		if rec.projectID != p.project.ID {
+			continue
+		}
+		if len(types) > 0 && !slices.Contains(types, rec.ip.Type) {
+			continue
+		}
+		ips = append(ips, s.renderIP(rec))
+	}
+	writePage(w, r, ips)
+}
+
+type updateIPRequest struct {
+	PTRRecord  *string            `json:"ptr_record"`
+	ARecord    *string            `json:"a_record"`
+	RoutedTo   *string            `json:"routed_to"`
+	TargetedTo *flexID            `json:"targeted_to"`
+	AssignedTo *flexID            `json:"assigned_to"`
+	Tags       *map[string]string `json:"tags"`
+}
+
+func (s *Server) createIP(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		updateIPRequest
+		Region        string `json:"region"`
+		DDoSScrubbing bool   `json:"ddos_scrubbing"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	region, ok := s.state.region(req.Region)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "region %q not found", req.Region)
+		return
+	}
+
+	rec := s.newIP(p.project.ID, "floating-ip", region)
+	rec.ip.DDoSScrubbing = req.DDoSScrubbing
+	if !s.applyIPUpdate(w, rec, req.updateIPRequest) {
+		delete(s.state.ips, rec.ip.ID)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.renderIP(rec))
+}
+
+func (s *Server) getIP(w http.ResponseWriter, r *http.Request) {
+	if ip, ok := s.findIP(w, r); ok {
+		writeJSON(w, http.StatusOK, s.renderIP(ip))
+	}
+}
+
+func (s *Server) updateIP(w http.ResponseWriter, r *http.Request) {
+	rec, ok := s.findIP(w, r)
+	if !ok {
+		return
+	}
+
+	var req updateIPRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if !s.applyIPUpdate(w, rec, req) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.renderIP(rec))
+}
+
+func (s *Server) applyIPUpdate(w http.ResponseWriter, rec *ipRecord, req updateIPRequest) bool {
+	target := req.TargetedTo
+	if target == nil {
+		target = req.AssignedTo
+	}
+	if target != nil {
+		if rec.ip.Type == "primary-ip" {
+			writeError(w, http.StatusUnprocessableEntity, "primary ip addresses can not be reassigned")
+			return false
+		}
+		if *target != 0 {
+			srv, ok := s.state.servers[int(*target)]
+			if !ok || srv.projectID != rec.projectID {
+				writeError(w, http.StatusUnprocessableEntity, "server %d not found", *target)
+				return false
+			}
+		}
+		rec.serverID = int(*target)
+		rec.ip.RoutedTo = cherrygo.RoutedTo{}
+	}
+
+	if req.RoutedTo != nil {
+		rec.ip.RoutedTo = cherrygo.RoutedTo{}
+		if *req.RoutedTo != "" {
+			to, ok := s.state.ips[*req.RoutedTo]
+			if !ok {
+				writeError(w, http.StatusUnprocessableEntity, "ip address %q not found", *req.RoutedTo)
+				return false
+			}
+			rec.serverID = 0
+			rec.ip.RoutedTo = cherrygo.RoutedTo{
+				ID:            to.ip.ID,
+				Address:       to.ip.Address,
+				AddressFamily: to.ip.AddressFamily,
+				CIDR:          to.ip.CIDR,
+				Gateway:       to.ip.Gateway,
+				Type:          to.ip.Type,
+				Region:        to.ip.Region,
+			}
+		}
+	}
+
+	if req.PTRRecord != nil {
+		rec.ip.PTRRecord = *req.PTRRecord
+	}
+	if req.ARecord != nil {
+		rec.ip.ARecord = *req.ARecord
+	}
+	if req.Tags != nil {
+		tags := *req.Tags
+		rec.ip.Tags = &tags
+	}
+	return true
+}
+
+func (s *Server) removeIP(w http.ResponseWriter, r *http.Request) {
+	rec, ok := s.findIP(w, r)
+	if !ok {
+		return
+	}
+	if rec.ip.Type == "primary-ip" {
+		writeError(w, http.StatusUnprocessableEntity, "primary ip addresses can not be removed")
+		return
+	}
+
+	delete(s.state.ips, rec.ip.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,319 @@
+// Package cherrygotest provides a stateful, in-memory fake of the Cherry Servers API,
+// so code built on cherrygo can be tested offline, end to end.
+//
+// The fake serves the endpoints used by cherrygo: teams, projects, servers and
+// their actions, IP addresses, storages and attachments, backup storages and
+// their methods, SSH keys, plans, prebuilt plans, images, regions, users and
+// billing cycles. It starts with a region, plans, images, billing cycles,
+// a backup plan and a current user, see [DefaultRegion] and [DefaultPlan].
+//
+// Servers and backup storages move through the pending, provisioning and
+// deployed statuses as the fake [Clock] is advanced:
+//
+//	fake := cherrygotest.NewServer()
+//	defer fake.Close()
+//
+//	c, err := fake.NewClient()
+//	// ... create a team, a project and a server ...
+//	fake.Clock().Advance(10 * time.Minute)
+//	srv, _, err := c.Servers.Get(ctx, srv.ID, nil) // srv.Status == "deployed"
+//
+// Failures can be injected per endpoint with [Server.InjectFailure] and
+// [Server.FailNextDeployment].
+package cherrygotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+// APIKey is the API key of clients created with [Server.NewClient].
+// The fake accepts any non-empty API key.
+const APIKey = "cherrygotest"
+
+// Server is a fake Cherry Servers API server.
+type Server struct {
+	// URL is the base URL of the server, without the API version prefix.
+	URL string
+
+	srv   *httptest.Server
+	clock *Clock
+
+	provisioningAfter time.Duration
+	deployedAfter     time.Duration
+
+	mu        sync.Mutex
+	state     state
+	endpoints []string
+	failures  map[string]*Failure
+}
+
+// Option is a fake server configuration option.
+type Option func(*Server)
+
+// WithClock makes the server use c instead of a clock set to 2025-01-01.
+func WithClock(c *Clock) Option {
+	return func(s *Server) {
+		s.clock = c
+	}
+}
+
+// WithTransitions sets how long after an order servers and backup storages
+// become provisioning and deployed. Defaults to 1 and 5 minutes.
+func WithTransitions(provisioning, deployed time.Duration) Option {
+	return func(s *Server) {
+		s.provisioningAfter = provisioning
+		s.deployedAfter = deployed
+	}
+}
+
+// NewServer starts a fake API server. The caller should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		clock:             NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		provisioningAfter: time.Minute,
+		deployedAfter:     5 * time.Minute,
+		failures:          make(map[string]*Failure),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.state = newState()
+
+	mux := http.NewServeMux()
+	s.registerTeams(mux)
+	s.registerServers(mux)
+	s.registerIPAddresses(mux)
+	s.registerStorages(mux)
+	s.registerBackups(mux)
+	s.registerCatalog(mux)
+	s.registerUsers(mux)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no route for %s %s", r.Method, r.URL.Path)
+	})
+
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Clock returns the clock of the server.
+func (s *Server) Clock() *Clock {
+	return s.clock
+}
+
+// NewClient creates a client for the fake server with [APIKey].
+// opts are applied after the URL and API key options.
+func (s *Server) NewClient(opts ...cherrygo.ClientOpt) (*cherrygo.Client, error) {
+	return cherrygo.NewClient(append([]cherrygo.ClientOpt{
+		cherrygo.WithURL(s.URL + "/v1/"),
+		cherrygo.WithAPIKey(APIKey),
+	}, opts...)...)
+}
+
+// Endpoints returns the endpoints served by the fake, as method and path patterns,
+// e.g. "GET /v1/servers/{server_id}".
+func (s *Server) Endpoints() []string {
+	return slices.Clone(s.endpoints)
+}
+
+// Failure is an error response injected into an endpoint.
+type Failure struct {
+	// StatusCode is the status code of the error response.
+	StatusCode int
+
+	// Times is the amount of requests that fail. Zero fails all requests,
+	// until the failure is cleared.
+	Times int
+
+	// RetryAfter sets the Retry-After header, in whole seconds, if not nil.
+	// Only used for status 429 and 503.
+	RetryAfter *time.Duration
+}
+
+// InjectFailure makes requests to endpoint fail with f, replacing any failure
+// already injected into it. endpoint is a method and path pattern, as returned
+// by [Server.Endpoints]. Panics if the fake does not serve endpoint.
+//
+// Failed requests do not change the state of the fake.
+func (s *Server) InjectFailure(endpoint string, f Failure) {
+	if !slices.Contains(s.endpoints, endpoint) {
+		panic(fmt.Sprintf("cherrygotest: unknown endpoint %q", endpoint))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[endpoint] = &f
+}
+
+// ClearFailures removes all injected failures.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.failures)
+}
+
+// FailNextDeployment makes the next ordered or reinstalled server end up
+// in the "failed deployment" status instead of being deployed.
+func (s *Server) FailNextDeployment() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.failNextDeployment = true
+}
+
+// handle registers h for pattern. Requests are authenticated, may fail with
+// an injected failure and are handled with the state locked.
+func (s *Server) handle(mux *http.ServeMux, pattern string, h http.HandlerFunc) {
+	s.endpoints = append(s.endpoints, pattern)
+
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") ||
+			strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") == "" {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if f := s.failure(pattern); f != nil {
+			if f.RetryAfter != nil && (f.StatusCode == http.StatusTooManyRequests || f.StatusCode == http.StatusServiceUnavailable) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+			}
+			writeError(w, f.StatusCode, "injected failure")
+			return
+		}
+
+		h(w, r)
+	})
+}
+
+// failure returns the failure injected into endpoint, if any, and counts it.
+func (s *Server) failure(endpoint string) *Failure {
+	f, ok := s.failures[endpoint]
+	if !ok {
+		return nil
+	}
+
+	if f.Times > 0 {
+		f.Times--
+		if f.Times == 0 {
+			delete(s.failures, endpoint)
+		}
+	}
+	return f
+}
+
+func (s *Server) now() time.Time {
+	return s.clock.Now()
+}
+
+// status returns the status of a resource ordered at ordered.
+func (s *Server) status(ordered time.Time, failed bool, deployed string) string {
+	elapsed := s.now().Sub(ordered)
+	switch {
+	case elapsed < s.provisioningAfter:
+		return "pending"
+	case elapsed < s.deployedAfter:
+		return "provisioning"
+	case failed:
+		return "failed deployment"
+	default:
+		return deployed
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]any{
+		"code":    status,
+		"message": fmt.Sprintf(format, args...),
+	})
+}
+
+// writePage writes the page of items selected by the limit and offset
+// query parameters, along with their total count.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+
+	q := r.URL.Query()
+	if offset, err := strconv.Atoi(q.Get("offset")); err == nil && offset > 0 {
+		items = items[min(offset, len(items)):]
+	}
+	if limit, err := strconv.Atoi(q.Get("limit")); err == nil && limit > 0 {
+		items = items[:min(limit, len(items))]
+	}
+	if items == nil {
+		items = []T{}
+	}
+
+	writeJSON(w, http.StatusOK, items)
+}
+
+// decode decodes the request body into v, writing an error response on failure.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+// pathID parses the integer path wildcard name, writing an error response on failure.
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "invalid %s %q", name, r.PathValue(name))
+		return 0, false
+	}
+	return id, true
+}
+
+// flexID is an ID that may be encoded as a JSON number or string.
+type flexID int
+
+func (id *flexID) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		n = json.Number(s)
+	}
+	if n == "" {
+		*id = 0
+		return nil
+	}
+
+	v, err := strconv.Atoi(string(n))
+	if err != nil {
+		return err
+	}
+	*id = flexID(v)
+	return nil
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
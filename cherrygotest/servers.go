@@ -0,0 +1,416 @@
+package cherrygotest
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerServers(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/projects/{project_id}/servers", s.listServers)
+	s.handle(mux, "POST /v1/projects/{project_id}/servers", s.createServer)
+	s.handle(mux, "GET /v1/servers/{server_id}", s.getServer)
+	s.handle(mux, "PUT /v1/servers/{server_id}", s.updateServer)
+	s.handle(mux, "DELETE /v1/servers/{server_id}", s.deleteServer)
+	s.handle(mux, "POST /v1/servers/{server_id}/actions", s.serverAction)
+	s.handle(mux, "GET /v1/servers/{server_id}/ssh-keys", s.listServerSSHKeys)
+	s.handle(mux, "GET /v1/cycles", s.listCycles)
+}
+
+// serverResponse adds the power state to the server, since it can be
+// requested with the fields query parameter.
+type serverResponse struct {
+	cherrygo.Server
+	Power string `json:"power,omitempty"`
+}
+
+func (s *Server) renderServer(rec *serverRecord) cherrygo.Server {
+	srv := rec.server
+
+	if !rec.ordered.IsZero() {
+		srv.Status = s.status(rec.ordered, rec.failed, rec.deployed)
+		srv.State = "pending"
+		switch srv.Status {
+		case "deployed", "allocated":
+			srv.State = "active"
+			srv.DeployedImage = cherrygo.DeployedImage{Name: srv.Image}
+		case "failed deployment":
+			srv.State = "failed"
+		}
+	}
+
+	if p, ok := s.state.projects[rec.projectID]; ok {
+		srv.Project = p.project
+	}
+
+	srv.IPAddresses = nil
+	for _, ip := range s.ipList() {
+		if ip.serverID == srv.ID {
+			srv.IPAddresses = append(srv.IPAddresses, s.renderIP(ip))
+		}
+	}
+
+	if st, ok := s.state.storages[rec.storageID]; ok {
+		srv.Storage = st.storage
+	}
+	if b, ok := s.state.backups[rec.backupID]; ok {
+		srv.Backup = s.renderBackup(b)
+	}
+
+	return srv
+}
+
+func (s *Server) findServer(w http.ResponseWriter, r *http.Request) (*serverRecord, bool) {
+	id, ok := pathID(w, r, "server_id")
+	if !ok {
+		return nil, false
+	}
+
+	srv, ok := s.state.servers[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "server %d not found", id)
+		return nil, false
+	}
+	return srv, true
+}
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	statuses := r.URL.Query()["status[]"]
+
+	var servers []cherrygo.Server
+	for _, rec := range sortedBy(s.state.servers, func(rec *serverRecord) int { return rec.server.ID }) {
+		if rec.projectID != p.project.ID {
+			continue
+		}
+
+		srv := s.renderServer(rec)
+		if len(statuses) > 0 && !slices.Contains(statuses, srv.Status) {
+			continue
+		}
+		servers = append(servers, srv)
+	}
+	writePage(w, r, servers)
+}
+
+func (s *Server) createServer(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.CreateServer
+	if !decode(w, r, &req) {
+		return
+	}
+
+	plan, ok := s.state.plan(req.Plan)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "plan %q not found", req.Plan)
+		return
+	}
+	region, ok := s.state.region(req.Region)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "region %q not found", req.Region)
+		return
+	}
+	image, ok := s.image(plan.Slug, req.Image)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "image %q is not available for plan %q", req.Image, plan.Slug)
+		return
+	}
+	if req.Image == "" && req.IPXE == "" {
+		writeError(w, http.StatusUnprocessableEntity, "image or ipxe is required")
+		return
+	}
+	if req.Cycle != "" && !slices.ContainsFunc(s.state.cycles, func(c cherrygo.ServerCycle) bool { return c.Slug == req.Cycle }) {
+		writeError(w, http.StatusUnprocessableEntity, "cycle %q not found", req.Cycle)
+		return
+	}
+	keys, ok := s.sshKeysByID(w, req.SSHKeys)
+	if !ok {
+		return
+	}
+	for _, id := range req.IPAddresses {
+		ip, ok := s.state.ips[id]
+		if !ok || ip.projectID != p.project.ID {
+			writeError(w, http.StatusUnprocessableEntity, "ip address %q not found", id)
+			return
+		}
+		if ip.serverID != 0 {
+			writeError(w, http.StatusUnprocessableEntity, "ip address %q is already assigned", id)
+			return
+		}
+	}
+
+	id := s.state.id()
+	rec := &serverRecord{
+		server: cherrygo.Server{
+			ID:           id,
+			Name:         plan.Name,
+			Href:         "/servers/" + itoa(id),
+			Hostname:     req.Hostname,
+			Username:     "root",
+			Image:        image.Name,
+			SpotInstance: req.SpotInstance,
+			Region:       region,
+			Plan:         plan,
+			SSHKeys:      keys,
+			Created:      s.now().Format(time.RFC3339),
+		},
+		projectID: p.project.ID,
+		ordered:   s.now(),
+		failed:    s.state.failNextDeployment,
+		deployed:  deployedStatus(image),
+		power:     "on",
+	}
+	s.state.failNextDeployment = false
+
+	if rec.server.Hostname == "" {
+		rec.server.Hostname = fmt.Sprintf("server-%d", id)
+	}
+	if req.Tags != nil {
+		rec.server.Tags = *req.Tags
+	}
+	if plan.Type == "baremetal" {
+		rec.server.BMC = cherrygo.BMC{User: "admin", Password: fmt.Sprintf("bmc-%d", id)}
+	}
+	if req.StorageID != 0 {
+		st, ok := s.state.storages[req.StorageID]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "storage %d not found", req.StorageID)
+			return
+		}
+		s.attachStorage(st, rec)
+	}
+
+	s.state.servers[id] = rec
+
+	primary := s.newIP(p.project.ID, "primary-ip", region)
+	primary.serverID = id
+	for _, ipID := range req.IPAddresses {
+		s.state.ips[ipID].serverID = id
+	}
+
+	writeJSON(w, http.StatusCreated, s.renderServer(rec))
+}
+
+// deployedStatus is the status servers end up in after deployment.
+// Custom installations, e.g. with iPXE, end up allocated.
+func deployedStatus(image cherrygo.Image) string {
+	if image.Slug == "" {
+		return "allocated"
+	}
+	return "deployed"
+}
+
+func (s *Server) image(plan, slug string) (cherrygo.Image, bool) {
+	if slug == "" {
+		return cherrygo.Image{}, true
+	}
+	for _, img := range s.state.images[plan] {
+		if img.Slug == slug {
+			return img, true
+		}
+	}
+	return cherrygo.Image{}, false
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request) {
+	rec, ok := s.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, serverResponse{
+		Server: s.renderServer(rec),
+		Power:  rec.power,
+	})
+}
+
+func (s *Server) updateServer(w http.ResponseWriter, r *http.Request) {
+	rec, ok := s.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateServer
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name != "" {
+		rec.server.Name = req.Name
+	}
+	if req.Hostname != "" {
+		rec.server.Hostname = req.Hostname
+	}
+	if req.Tags != nil {
+		rec.server.Tags = *req.Tags
+	}
+	if req.BGP != nil {
+		rec.server.BGP.Enabled = *req.BGP
+	}
+
+	writeJSON(w, http.StatusOK, s.renderServer(rec))
+}
+
+func (s *Server) deleteServer(w http.ResponseWriter, r *http.Request) {
+	rec, ok := s.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	id := rec.server.ID
+	for ipID, ip := range s.state.ips {
+		if ip.serverID != id {
+			continue
+		}
+		if ip.ip.Type == "primary-ip" {
+			delete(s.state.ips, ipID)
+		} else {
+			ip.serverID = 0
+		}
+	}
+	if st, ok := s.state.storages[rec.storageID]; ok {
+		st.storage.AttachedTo = cherrygo.AttachedTo{}
+	}
+	delete(s.state.backups, rec.backupID)
+	delete(s.state.servers, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type serverActionRequest struct {
+	Type      string   `json:"type"`
+	Image     string   `json:"image"`
+	Hostname  string   `json:"hostname"`
+	Password  string   `json:"password"`
+	IPXE      string   `json:"ipxe"`
+	SSHKeys   []string `json:"ssh_keys"`
+	Plan      string   `json:"plan"`
+	AllowedIP string   `json:"allowed_ip"`
+}
+
+func (s *Server) serverAction(w http.ResponseWriter, r *http.Request) {
+	rec, ok := s.findServer(w, r)
+	if !ok {
+		return
+	}
+
+	var req serverActionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	switch req.Type {
+	case "power_on":
+		rec.power = "on"
+	case "power_off":
+		rec.power = "off"
+	case "reboot":
+		rec.power = "on"
+	case "enter-rescue-mode":
+		if req.Password == "" {
+			writeError(w, http.StatusUnprocessableEntity, "password is required")
+			return
+		}
+		rec.server.State = "rescue"
+	case "exit-rescue-mode":
+		rec.server.State = "active"
+	case "reset-bmc-password":
+		if rec.server.Plan.Type != "baremetal" {
+			writeError(w, http.StatusUnprocessableEntity, "server %d has no BMC", rec.server.ID)
+			return
+		}
+		rec.server.BMC.Password = fmt.Sprintf("bmc-%d-%d", rec.server.ID, s.state.id())
+	case "create-console-access":
+		if rec.server.Plan.Type != "baremetal" {
+			writeError(w, http.StatusUnprocessableEntity, "server %d has no BMC", rec.server.ID)
+			return
+		}
+		if req.AllowedIP != "" {
+			ip, err := netip.ParseAddr(req.AllowedIP)
+			if err != nil || !ip.Is4() {
+				writeError(w, http.StatusUnprocessableEntity, "invalid allowed_ip %q", req.AllowedIP)
+				return
+			}
+			rec.server.BMC.AllowedIP = ip
+		}
+		rec.server.BMC.Expires = s.now().Add(time.Hour)
+	case "reinstall":
+		image, ok := s.image(rec.server.Plan.Slug, req.Image)
+		if !ok || (req.Image == "" && req.IPXE == "") {
+			writeError(w, http.StatusUnprocessableEntity, "image %q is not available for plan %q", req.Image, rec.server.Plan.Slug)
+			return
+		}
+		if req.Password == "" {
+			writeError(w, http.StatusUnprocessableEntity, "password is required")
+			return
+		}
+		keys, ok := s.sshKeysByID(w, req.SSHKeys)
+		if !ok {
+			return
+		}
+		rec.server.Image = image.Name
+		rec.server.SSHKeys = keys
+		if req.Hostname != "" {
+			rec.server.Hostname = req.Hostname
+		}
+		rec.ordered = s.now()
+		rec.failed = s.state.failNextDeployment
+		rec.deployed = deployedStatus(image)
+		s.state.failNextDeployment = false
+	case "upgrade":
+		plan, ok := s.state.plan(req.Plan)
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "plan %q not found", req.Plan)
+			return
+		}
+		if rec.server.Plan.Type != "vps" || plan.Type != "vps" {
+			writeError(w, http.StatusUnprocessableEntity, "only virtual servers can be upgraded")
+			return
+		}
+		rec.server.Plan = plan
+		rec.server.Name = plan.Name
+	default:
+		writeError(w, http.StatusBadRequest, "unknown action %q", req.Type)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, s.renderServer(rec))
+}
+
+func (s *Server) listServerSSHKeys(w http.ResponseWriter, r *http.Request) {
+	if rec, ok := s.findServer(w, r); ok {
+		writePage(w, r, rec.server.SSHKeys)
+	}
+}
+
+func (s *Server) listCycles(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, s.state.cycles)
+}
+
+// sshKeysByID looks up SSH keys by their string IDs, writing an error response
+// if any are not found.
+func (s *Server) sshKeysByID(w http.ResponseWriter, ids []string) ([]cherrygo.SSHKey, bool) {
+	var keys []cherrygo.SSHKey
+	for _, id := range ids {
+		n, err := strconv.Atoi(id)
+		key, ok := s.state.sshKeys[n]
+		if err != nil || !ok {
+			writeError(w, http.StatusUnprocessableEntity, "ssh key %q not found", id)
+			return nil, false
+		}
+		keys = append(keys, *key)
+	}
+	return keys, true
+}
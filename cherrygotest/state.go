@@ -0,0 +1,342 @@
+package cherrygotest
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+// DefaultRegion is the region the fake starts with.
+var DefaultRegion = cherrygo.Region{
+	ID:         1,
+	Name:       "EU Nord-1",
+	Slug:       "eu_nord_1",
+	RegionISO2: "LT",
+	Location:   "Lithuania, Siauliai",
+	Href:       "/regions/1",
+}
+
+// DefaultPlan is the virtual server plan the fake starts with.
+// It also has a bare metal plan, "e5_1620v4".
+var DefaultPlan = cherrygo.Plan{
+	ID:       1,
+	Name:     "Cloud VPS 1",
+	Slug:     "cloud_vps_1",
+	Type:     "vps",
+	Category: "shared",
+	Specs: cherrygo.Specs{
+		CPUs:   cherrygo.CPUs{Count: 1, Name: "vCPU", Cores: 1},
+		Memory: cherrygo.Memory{Total: 1, Unit: "GB"},
+	},
+	Pricing: []cherrygo.Pricing{{Price: 0.01, Currency: "EUR", Unit: "Hourly"}},
+}
+
+// DefaultImage is the image the fake plans start with.
+var DefaultImage = cherrygo.Image{ID: 1, Name: "Ubuntu 24.04 64bit", Slug: "ubuntu_24_04_64bit"}
+
+type state struct {
+	nextID   int
+	nextIPID int
+
+	teams     map[int]*cherrygo.Team
+	projects  map[int]*projectRecord
+	servers   map[int]*serverRecord
+	ips       map[string]*ipRecord
+	storages  map[int]*storageRecord
+	backups   map[int]*backupRecord
+	sshKeys   map[int]*cherrygo.SSHKey
+	users     map[int]*cherrygo.User
+	currentID int
+
+	regions     []cherrygo.Region
+	plans       []cherrygo.Plan
+	prebuilts   map[string][]cherrygo.PrebuiltPlan
+	images      map[string][]cherrygo.Image
+	cycles      []cherrygo.ServerCycle
+	backupPlans []cherrygo.BackupStoragePlan
+
+	failNextDeployment bool
+}
+
+type projectRecord struct {
+	project cherrygo.Project
+	teamID  int
+}
+
+type serverRecord struct {
+	server    cherrygo.Server
+	projectID int
+	ordered   time.Time
+	failed    bool
+	deployed  string
+	power     string
+	storageID int
+	backupID  int
+}
+
+type ipRecord struct {
+	ip        cherrygo.IPAddress
+	seq       int
+	projectID int
+	serverID  int
+}
+
+type storageRecord struct {
+	storage   cherrygo.BlockStorage
+	projectID int
+}
+
+type backupRecord struct {
+	backup    cherrygo.BackupStorage
+	projectID int
+	serverID  int
+	ordered   time.Time
+}
+
+func newState() state {
+	baremetal := DefaultPlan
+	baremetal.ID = 2
+	baremetal.Name = "E5-1620v4"
+	baremetal.Slug = "e5_1620v4"
+	baremetal.Type = "baremetal"
+	baremetal.Category = "baremetal"
+	baremetal.Specs = cherrygo.Specs{
+		CPUs:   cherrygo.CPUs{Count: 1, Name: "E5-1620v4", Cores: 4},
+		Memory: cherrygo.Memory{Total: 32, Unit: "GB"},
+	}
+
+	s := state{
+		nextID:   1000,
+		teams:    make(map[int]*cherrygo.Team),
+		projects: make(map[int]*projectRecord),
+		servers:  make(map[int]*serverRecord),
+		ips:      make(map[string]*ipRecord),
+		storages: make(map[int]*storageRecord),
+		backups:  make(map[int]*backupRecord),
+		sshKeys:  make(map[int]*cherrygo.SSHKey),
+		users: map[int]*cherrygo.User{
+			1: {ID: 1, FirstName: "Test", LastName: "User", Email: "test@example.com", EmailVerified: true, Href: "/users/1"},
+		},
+		currentID: 1,
+		regions:   []cherrygo.Region{DefaultRegion},
+		prebuilts: make(map[string][]cherrygo.PrebuiltPlan),
+		images: map[string][]cherrygo.Image{
+			DefaultPlan.Slug: {DefaultImage},
+			baremetal.Slug:   {DefaultImage},
+		},
+		cycles: []cherrygo.ServerCycle{
+			{ID: 1, Name: "Hourly", Slug: "hourly"},
+			{ID: 6, Name: "Monthly", Slug: "monthly"},
+		},
+		backupPlans: []cherrygo.BackupStoragePlan{{
+			ID:            1,
+			Name:          "Backup 100",
+			Slug:          "backup_100",
+			SizeGigabytes: 100,
+			Regions:       []cherrygo.Region{DefaultRegion},
+			Href:          "/backup-storage-plans/1",
+		}},
+	}
+
+	for _, p := range []cherrygo.Plan{DefaultPlan, baremetal} {
+		p.AvailableRegions = []cherrygo.AvailableRegions{{Region: &DefaultRegion, StockQty: 100, SpotQty: 10}}
+		p.Softwares = []cherrygo.SoftwareImage{{Image: cherrygo.SoftwareImageSpecs{Name: DefaultImage.Name, Slug: DefaultImage.Slug}}}
+		s.plans = append(s.plans, p)
+	}
+
+	return s
+}
+
+func (st *state) id() int {
+	st.nextID++
+	return st.nextID
+}
+
+func (st *state) region(slug string) (cherrygo.Region, bool) {
+	for _, r := range st.regions {
+		if r.Slug == slug {
+			return r, true
+		}
+	}
+	return cherrygo.Region{}, false
+}
+
+func (st *state) plan(slugOrID string) (cherrygo.Plan, bool) {
+	for _, p := range st.plans {
+		if p.Slug == slugOrID || itoa(p.ID) == slugOrID {
+			return p, true
+		}
+	}
+	return cherrygo.Plan{}, false
+}
+
+// sortedBy returns the values of m sorted by key.
+func sortedBy[K comparable, V any, O cmp.Ordered](m map[K]V, key func(V) O) []V {
+	return slices.SortedFunc(maps.Values(m), func(a, b V) int {
+		return cmp.Compare(key(a), key(b))
+	})
+}
+
+// AddTeam adds a team to the fake and returns it with its assigned ID.
+func (s *Server) AddTeam(t cherrygo.Team) cherrygo.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t.ID = s.state.id()
+	t.Href = "/teams/" + itoa(t.ID)
+	s.state.teams[t.ID] = &t
+	return t
+}
+
+// AddProject adds a project to a team and returns it with its assigned ID.
+func (s *Server) AddProject(teamID int, p cherrygo.Project) cherrygo.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p.ID = s.state.id()
+	p.Href = "/projects/" + itoa(p.ID)
+	s.state.projects[p.ID] = &projectRecord{project: p, teamID: teamID}
+	return p
+}
+
+// AddServer adds a server to a project and returns it with its assigned ID.
+// Servers added without a status are deployed.
+func (s *Server) AddServer(projectID int, srv cherrygo.Server) cherrygo.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := &serverRecord{
+		server:    srv,
+		projectID: projectID,
+		ordered:   s.now().Add(-s.deployedAfter),
+		deployed:  "deployed",
+		power:     "on",
+	}
+	rec.server.ID = s.state.id()
+	rec.server.Href = "/servers/" + itoa(rec.server.ID)
+	if srv.Status != "" {
+		// Keep the status as is.
+		rec.ordered = time.Time{}
+	}
+	if rec.server.Created == "" {
+		rec.server.Created = s.now().Format(time.RFC3339)
+	}
+	s.state.servers[rec.server.ID] = rec
+	return s.renderServer(rec)
+}
+
+// AddIPAddress adds an IP address to a project and returns it with its assigned ID.
+// IP addresses added without a type are floating IPs. An address is allocated
+// if ip has none.
+func (s *Server) AddIPAddress(projectID int, ip cherrygo.IPAddress) cherrygo.IPAddress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ip.Type == "" {
+		ip.Type = "floating-ip"
+	}
+
+	rec := s.newIP(projectID, ip.Type, ip.Region)
+	generated := rec.ip
+	rec.ip = ip
+	rec.ip.ID = generated.ID
+	rec.ip.Href = generated.Href
+	if ip.Address == "" {
+		rec.ip.Address = generated.Address
+		rec.ip.AddressFamily = generated.AddressFamily
+		rec.ip.CIDR = generated.CIDR
+		rec.ip.Gateway = generated.Gateway
+	}
+	return s.renderIP(rec)
+}
+
+// AddStorage adds an elastic block storage to a project and returns it with its assigned ID.
+func (s *Server) AddStorage(projectID int, bs cherrygo.BlockStorage) cherrygo.BlockStorage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bs.ID = s.state.id()
+	bs.Href = "/storages/" + itoa(bs.ID)
+	s.state.storages[bs.ID] = &storageRecord{storage: bs, projectID: projectID}
+	return bs
+}
+
+// AddSSHKey adds an SSH key and returns it with its assigned ID.
+func (s *Server) AddSSHKey(key cherrygo.SSHKey) cherrygo.SSHKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key.ID = s.state.id()
+	key.Href = "/ssh-keys/" + itoa(key.ID)
+	s.state.sshKeys[key.ID] = &key
+	return key
+}
+
+// AddUser adds a user and returns it with its assigned ID.
+func (s *Server) AddUser(u cherrygo.User) cherrygo.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u.ID = s.state.id()
+	u.Href = "/users/" + itoa(u.ID)
+	s.state.users[u.ID] = &u
+	return u
+}
+
+// SetCurrentUser sets the user that owns the API key.
+func (s *Server) SetCurrentUser(userID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.currentID = userID
+}
+
+// AddRegion adds a region.
+func (s *Server) AddRegion(r cherrygo.Region) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.regions = append(s.state.regions, r)
+}
+
+// AddPlan adds a plan, with images that can be installed on its servers.
+func (s *Server) AddPlan(p cherrygo.Plan, images ...cherrygo.Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.plans = append(s.state.plans, p)
+	s.state.images[p.Slug] = append(s.state.images[p.Slug], images...)
+}
+
+// AddPrebuiltPlans adds prebuilt variants of a base plan.
+func (s *Server) AddPrebuiltPlans(basePlan string, plans ...cherrygo.PrebuiltPlan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.prebuilts[basePlan] = append(s.state.prebuilts[basePlan], plans...)
+}
+
+// AddCycle adds a billing cycle.
+func (s *Server) AddCycle(c cherrygo.ServerCycle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.cycles = append(s.state.cycles, c)
+}
+
+// AddBackupPlan adds a backup storage plan.
+func (s *Server) AddBackupPlan(p cherrygo.BackupStoragePlan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.backupPlans = append(s.state.backupPlans, p)
+}
+
+// ServerByID returns the current state of a server, for assertions.
+func (s *Server) ServerByID(id int) (cherrygo.Server, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.state.servers[id]
+	if !ok {
+		return cherrygo.Server{}, false
+	}
+	return s.renderServer(rec), true
+}
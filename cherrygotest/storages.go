@@ -0,0 +1,190 @@
+package cherrygotest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerStorages(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/projects/{project_id}/storages", s.listStorages)
+	s.handle(mux, "POST /v1/projects/{project_id}/storages", s.createStorage)
+	s.handle(mux, "GET /v1/storages/{storage_id}", s.getStorage)
+	s.handle(mux, "PUT /v1/storages/{storage_id}", s.updateStorage)
+	s.handle(mux, "DELETE /v1/storages/{storage_id}", s.deleteStorage)
+	s.handle(mux, "POST /v1/storages/{storage_id}/attachments", s.attach)
+	s.handle(mux, "DELETE /v1/storages/{storage_id}/attachments", s.detach)
+}
+
+func (s *Server) findStorage(w http.ResponseWriter, r *http.Request) (*storageRecord, bool) {
+	id, ok := pathID(w, r, "storage_id")
+	if !ok {
+		return nil, false
+	}
+
+	st, ok := s.state.storages[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "storage %d not found", id)
+		return nil, false
+	}
+	return st, true
+}
+
+func (s *Server) listStorages(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var storages []cherrygo.BlockStorage
+	for _, st := range sortedBy(s.state.storages, func(st *storageRecord) int { return st.storage.ID }) {
+		if st.projectID == p.project.ID {
+			storages = append(storages, st.storage)
+		}
+	}
+	writePage(w, r, storages)
+}
+
+func (s *Server) createStorage(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.CreateStorage
+	if !decode(w, r, &req) {
+		return
+	}
+
+	region, ok := s.state.region(req.Region)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "region %q not found", req.Region)
+		return
+	}
+	if req.Size <= 0 {
+		writeError(w, http.StatusUnprocessableEntity, "size must be positive")
+		return
+	}
+
+	id := s.state.id()
+	st := &storageRecord{
+		storage: cherrygo.BlockStorage{
+			ID:            id,
+			Name:          fmt.Sprintf("storage-%d", id),
+			Href:          "/storages/" + itoa(id),
+			Size:          req.Size,
+			AllowEditSize: true,
+			Unit:          "GB",
+			Description:   req.Description,
+			VLANID:        itoa(2000 + id%1000),
+			VLANIP:        fmt.Sprintf("10.168.%d.%d", id/256%256, id%256),
+			Initiator:     fmt.Sprintf("iqn.2019-03.com.cherryservers:storage-%d", id),
+			DiscoveryIP:   "10.168.255.1",
+			Region:        region,
+		},
+		projectID: p.project.ID,
+	}
+	s.state.storages[id] = st
+
+	writeJSON(w, http.StatusCreated, st.storage)
+}
+
+func (s *Server) getStorage(w http.ResponseWriter, r *http.Request) {
+	if st, ok := s.findStorage(w, r); ok {
+		writeJSON(w, http.StatusOK, st.storage)
+	}
+}
+
+func (s *Server) updateStorage(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.findStorage(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateStorage
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Size != 0 {
+		if req.Size < st.storage.Size {
+			writeError(w, http.StatusUnprocessableEntity, "storage size can not be decreased")
+			return
+		}
+		st.storage.Size = req.Size
+	}
+	if req.Description != "" {
+		st.storage.Description = req.Description
+	}
+
+	writeJSON(w, http.StatusOK, st.storage)
+}
+
+func (s *Server) deleteStorage(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.findStorage(w, r)
+	if !ok {
+		return
+	}
+	if st.storage.AttachedTo.ID != 0 {
+		writeError(w, http.StatusConflict, "storage %d is attached to server %d", st.storage.ID, st.storage.AttachedTo.ID)
+		return
+	}
+
+	delete(s.state.storages, st.storage.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) attach(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.findStorage(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.AttachTo
+	if !decode(w, r, &req) {
+		return
+	}
+
+	srv, ok := s.state.servers[req.AttachTo]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "server %d not found", req.AttachTo)
+		return
+	}
+	if st.storage.AttachedTo.ID != 0 {
+		writeError(w, http.StatusConflict, "storage %d is already attached to server %d", st.storage.ID, st.storage.AttachedTo.ID)
+		return
+	}
+	if srv.server.Region.Slug != st.storage.Region.Slug {
+		writeError(w, http.StatusUnprocessableEntity, "storage and server must be in the same region")
+		return
+	}
+
+	s.attachStorage(st, srv)
+	writeJSON(w, http.StatusCreated, st.storage)
+}
+
+func (s *Server) attachStorage(st *storageRecord, srv *serverRecord) {
+	st.storage.AttachedTo = cherrygo.AttachedTo{
+		ID:       srv.server.ID,
+		Hostname: srv.server.Hostname,
+		Href:     srv.server.Href,
+	}
+	srv.storageID = st.storage.ID
+}
+
+func (s *Server) detach(w http.ResponseWriter, r *http.Request) {
+	st, ok := s.findStorage(w, r)
+	if !ok {
+		return
+	}
+	if st.storage.AttachedTo.ID == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "storage %d is not attached", st.storage.ID)
+		return
+	}
+
+	if srv, ok := s.state.servers[st.storage.AttachedTo.ID]; ok {
+		srv.storageID = 0
+	}
+	st.storage.AttachedTo = cherrygo.AttachedTo{}
+	w.WriteHeader(http.StatusNoContent)
+}
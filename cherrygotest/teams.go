@@ -0,0 +1,237 @@
+package cherrygotest
+
+import (
+	"net/http"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerTeams(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/teams", s.listTeams)
+	s.handle(mux, "POST /v1/teams", s.createTeam)
+	s.handle(mux, "GET /v1/teams/{team_id}", s.getTeam)
+	s.handle(mux, "PUT /v1/teams/{team_id}", s.updateTeam)
+	s.handle(mux, "DELETE /v1/teams/{team_id}", s.deleteTeam)
+
+	s.handle(mux, "GET /v1/teams/{team_id}/projects", s.listProjects)
+	s.handle(mux, "POST /v1/teams/{team_id}/projects", s.createProject)
+	s.handle(mux, "GET /v1/projects/{project_id}", s.getProject)
+	s.handle(mux, "PUT /v1/projects/{project_id}", s.updateProject)
+	s.handle(mux, "DELETE /v1/projects/{project_id}", s.deleteProject)
+	s.handle(mux, "GET /v1/projects/{project_id}/ssh-keys", s.listProjectSSHKeys)
+}
+
+func (s *Server) renderTeam(t *cherrygo.Team) cherrygo.Team {
+	team := *t
+	team.Projects = nil
+	for _, p := range sortedBy(s.state.projects, func(p *projectRecord) int { return p.project.ID }) {
+		if p.teamID == t.ID {
+			team.Projects = append(team.Projects, p.project)
+		}
+	}
+	return team
+}
+
+func (s *Server) findTeam(w http.ResponseWriter, r *http.Request) (*cherrygo.Team, bool) {
+	id, ok := pathID(w, r, "team_id")
+	if !ok {
+		return nil, false
+	}
+
+	t, ok := s.state.teams[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "team %d not found", id)
+		return nil, false
+	}
+	return t, true
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	var teams []cherrygo.Team
+	for _, t := range sortedBy(s.state.teams, func(t *cherrygo.Team) int { return t.ID }) {
+		teams = append(teams, s.renderTeam(t))
+	}
+	writePage(w, r, teams)
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	var req cherrygo.CreateTeam
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	id := s.state.id()
+	t := &cherrygo.Team{
+		ID:      id,
+		Name:    req.Name,
+		Billing: cherrygo.Billing{Type: req.Type, Currency: req.Currency},
+		Href:    "/teams/" + itoa(id),
+	}
+	s.state.teams[id] = t
+
+	writeJSON(w, http.StatusCreated, s.renderTeam(t))
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	if t, ok := s.findTeam(w, r); ok {
+		writeJSON(w, http.StatusOK, s.renderTeam(t))
+	}
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTeam(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateTeam
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name != nil {
+		t.Name = *req.Name
+	}
+	if req.Type != nil {
+		t.Billing.Type = *req.Type
+	}
+	if req.Currency != nil {
+		t.Billing.Currency = *req.Currency
+	}
+
+	writeJSON(w, http.StatusOK, s.renderTeam(t))
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTeam(w, r)
+	if !ok {
+		return
+	}
+
+	for _, p := range s.state.projects {
+		if p.teamID == t.ID {
+			writeError(w, http.StatusConflict, "team %d has projects", t.ID)
+			return
+		}
+	}
+
+	delete(s.state.teams, t.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) findProject(w http.ResponseWriter, r *http.Request) (*projectRecord, bool) {
+	id, ok := pathID(w, r, "project_id")
+	if !ok {
+		return nil, false
+	}
+
+	p, ok := s.state.projects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "project %d not found", id)
+		return nil, false
+	}
+	return p, true
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTeam(w, r)
+	if !ok {
+		return
+	}
+
+	var projects []cherrygo.Project
+	for _, p := range sortedBy(s.state.projects, func(p *projectRecord) int { return p.project.ID }) {
+		if p.teamID == t.ID {
+			projects = append(projects, p.project)
+		}
+	}
+	writePage(w, r, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.findTeam(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.CreateProject
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "name is required")
+		return
+	}
+
+	id := s.state.id()
+	p := &projectRecord{
+		project: cherrygo.Project{
+			ID:   id,
+			Name: req.Name,
+			BGP:  cherrygo.ProjectBGP{Enabled: req.BGP},
+			Href: "/projects/" + itoa(id),
+		},
+		teamID: t.ID,
+	}
+	if req.BGP {
+		p.project.BGP.LocalASN = 65000 + id%1000
+	}
+	s.state.projects[id] = p
+
+	writeJSON(w, http.StatusCreated, p.project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	if p, ok := s.findProject(w, r); ok {
+		writeJSON(w, http.StatusOK, p.project)
+	}
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateProject
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name != nil {
+		p.project.Name = *req.Name
+	}
+	if req.BGP != nil {
+		p.project.BGP.Enabled = *req.BGP
+		if *req.BGP && p.project.BGP.LocalASN == 0 {
+			p.project.BGP.LocalASN = 65000 + p.project.ID%1000
+		}
+	}
+
+	writeJSON(w, http.StatusOK, p.project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.findProject(w, r)
+	if !ok {
+		return
+	}
+
+	for _, srv := range s.state.servers {
+		if srv.projectID == p.project.ID {
+			writeError(w, http.StatusConflict, "project %d has servers", p.project.ID)
+			return
+		}
+	}
+
+	delete(s.state.projects, p.project.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listProjectSSHKeys(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.findProject(w, r); ok {
+		writePage(w, r, s.sshKeyList())
+	}
+}
@@ -0,0 +1,148 @@
+package cherrygotest
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+func (s *Server) registerUsers(mux *http.ServeMux) {
+	s.handle(mux, "GET /v1/user", s.currentUser)
+	s.handle(mux, "GET /v1/users/{user_id}", s.getUser)
+
+	s.handle(mux, "GET /v1/ssh-keys", s.listSSHKeys)
+	s.handle(mux, "POST /v1/ssh-keys", s.createSSHKey)
+	s.handle(mux, "GET /v1/ssh-keys/{ssh_key_id}", s.getSSHKey)
+	s.handle(mux, "PUT /v1/ssh-keys/{ssh_key_id}", s.updateSSHKey)
+	s.handle(mux, "DELETE /v1/ssh-keys/{ssh_key_id}", s.deleteSSHKey)
+}
+
+func (s *Server) currentUser(w http.ResponseWriter, _ *http.Request) {
+	u, ok := s.state.users[s.state.currentID]
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid API key")
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "user_id")
+	if !ok {
+		return
+	}
+
+	u, ok := s.state.users[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "user %d not found", id)
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) sshKeyList() []cherrygo.SSHKey {
+	var keys []cherrygo.SSHKey
+	for _, key := range sortedBy(s.state.sshKeys, func(k *cherrygo.SSHKey) int { return k.ID }) {
+		keys = append(keys, *key)
+	}
+	return keys
+}
+
+func (s *Server) findSSHKey(w http.ResponseWriter, r *http.Request) (*cherrygo.SSHKey, bool) {
+	id, ok := pathID(w, r, "ssh_key_id")
+	if !ok {
+		return nil, false
+	}
+
+	key, ok := s.state.sshKeys[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ssh key %d not found", id)
+		return nil, false
+	}
+	return key, true
+}
+
+// fingerprint is a stand in for the MD5 fingerprint of a public key.
+func fingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	parts := make([]string, 16)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%02x", sum[i])
+	}
+	return strings.Join(parts, ":")
+}
+
+func (s *Server) listSSHKeys(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, s.sshKeyList())
+}
+
+func (s *Server) createSSHKey(w http.ResponseWriter, r *http.Request) {
+	var req cherrygo.CreateSSHKey
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Label == "" || req.Key == "" {
+		writeError(w, http.StatusUnprocessableEntity, "label and key are required")
+		return
+	}
+
+	id := s.state.id()
+	now := s.now().Format(time.RFC3339)
+	key := &cherrygo.SSHKey{
+		ID:          id,
+		Label:       req.Label,
+		Key:         req.Key,
+		Fingerprint: fingerprint(req.Key),
+		Created:     now,
+		Updated:     now,
+		Href:        "/ssh-keys/" + itoa(id),
+	}
+	if u, ok := s.state.users[s.state.currentID]; ok {
+		key.User = *u
+	}
+	s.state.sshKeys[id] = key
+
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func (s *Server) getSSHKey(w http.ResponseWriter, r *http.Request) {
+	if key, ok := s.findSSHKey(w, r); ok {
+		writeJSON(w, http.StatusOK, key)
+	}
+}
+
+func (s *Server) updateSSHKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := s.findSSHKey(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateSSHKey
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Label != nil {
+		key.Label = *req.Label
+	}
+	if req.Key != nil {
+		key.Key = *req.Key
+		key.Fingerprint = fingerprint(*req.Key)
+	}
+	key.Updated = s.now().Format(time.RFC3339)
+
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) deleteSSHKey(w http.ResponseWriter, r *http.Request) {
+	key, ok := s.findSSHKey(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.state.sshKeys, key.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
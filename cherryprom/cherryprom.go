@@ -0,0 +1,120 @@
+// Package cherryprom provides a Prometheus implementation of [cherrygo.Metrics].
+//
+// Register the collector and pass it to the client:
+//
+//	m := cherryprom.New()
+//	prometheus.MustRegister(m)
+//	c, err := cherrygo.NewClient(cherrygo.WithMetrics(m))
+//
+// It's a module of its own, so clients that don't use it
+// don't depend on the Prometheus client library.
+package cherryprom
+
+import (
+	"strconv"
+
+	"github.com/cherryservers/cherrygo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "cherrygo"
+
+// Metrics is a [prometheus.Collector] that records client metrics.
+// Endpoints are labelled by templated path, e.g. /v1/servers/{server_id},
+// or "other" if the path matches no known endpoint.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	polls    *prometheus.CounterVec
+}
+
+var _ cherrygo.Metrics = (*Metrics)(nil)
+var _ prometheus.Collector = (*Metrics)(nil)
+
+// New creates a collector with metrics in the cherrygo namespace:
+//
+//   - cherrygo_requests_total, by method, endpoint, operation and code.
+//     The code is the response status code, or "error" for transport errors.
+//   - cherrygo_requests_in_flight, by method, endpoint and operation.
+//   - cherrygo_request_duration_seconds, by method, endpoint and operation.
+//   - cherrygo_retries_total, by method, endpoint and operation.
+//   - cherrygo_poll_iterations_total, by operation and observed status.
+func New() *Metrics {
+	requestLabels := []string{"method", "endpoint", "operation"}
+
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of Cherry Servers API calls, after retries.",
+		}, append(requestLabels, "code")),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "requests_in_flight",
+			Help:      "Number of Cherry Servers API calls in flight.",
+		}, requestLabels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of Cherry Servers API calls, including retries.",
+			Buckets:   prometheus.DefBuckets,
+		}, requestLabels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Total number of retried Cherry Servers API request attempts.",
+		}, requestLabels),
+		polls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "poll_iterations_total",
+			Help:      "Total number of polling iterations, by observed status.",
+		}, []string{"operation", "status"}),
+	}
+}
+
+// Describe implements [prometheus.Collector].
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.inFlight.Describe(ch)
+	m.duration.Describe(ch)
+	m.retries.Describe(ch)
+	m.polls.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.inFlight.Collect(ch)
+	m.duration.Collect(ch)
+	m.retries.Collect(ch)
+	m.polls.Collect(ch)
+}
+
+// RequestStarted implements [cherrygo.Metrics].
+func (m *Metrics) RequestStarted(info cherrygo.RequestInfo) {
+	m.inFlight.WithLabelValues(info.Method, info.Endpoint, info.Operation).Inc()
+}
+
+// RequestFinished implements [cherrygo.Metrics].
+func (m *Metrics) RequestFinished(info cherrygo.RequestInfo, result cherrygo.RequestResult) {
+	code := "error"
+	if result.Err == nil {
+		code = strconv.Itoa(result.StatusCode)
+	}
+
+	m.inFlight.WithLabelValues(info.Method, info.Endpoint, info.Operation).Dec()
+	m.requests.WithLabelValues(info.Method, info.Endpoint, info.Operation, code).Inc()
+	m.duration.WithLabelValues(info.Method, info.Endpoint, info.Operation).Observe(result.Duration.Seconds())
+}
+
+// RetryScheduled implements [cherrygo.Metrics].
+func (m *Metrics) RetryScheduled(info cherrygo.RequestInfo, _ cherrygo.RetryInfo) {
+	m.retries.WithLabelValues(info.Method, info.Endpoint, info.Operation).Inc()
+}
+
+// PollIteration implements [cherrygo.Metrics].
+func (m *Metrics) PollIteration(info cherrygo.PollInfo) {
+	m.polls.WithLabelValues(info.Operation, info.Status).Inc()
+}
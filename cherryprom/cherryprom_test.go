@@ -0,0 +1,61 @@
+package cherryprom_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4"
+	"github.com/cherryservers/cherrygo/v4/cherryprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	m := cherryprom.New()
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(m))
+
+	info := cherrygo.RequestInfo{
+		Method:    "GET",
+		Endpoint:  "/v1/servers/{server_id}",
+		Operation: "Servers.Get",
+	}
+
+	m.RequestStarted(info)
+	m.RetryScheduled(info, cherrygo.RetryInfo{Attempt: 1, Delay: time.Second})
+	m.RequestFinished(info, cherrygo.RequestResult{StatusCode: 200, Duration: time.Second, Attempts: 2})
+
+	m.RequestStarted(info)
+	m.RequestFinished(info, cherrygo.RequestResult{Err: errors.New("connection refused"), Attempts: 1})
+
+	m.PollIteration(cherrygo.PollInfo{Operation: "Servers.WaitForStatus", Iteration: 1, Status: "deploying"})
+
+	expected := `
+# HELP cherrygo_requests_total Total number of Cherry Servers API calls, after retries.
+# TYPE cherrygo_requests_total counter
+cherrygo_requests_total{code="200",endpoint="/v1/servers/{server_id}",method="GET",operation="Servers.Get"} 1
+cherrygo_requests_total{code="error",endpoint="/v1/servers/{server_id}",method="GET",operation="Servers.Get"} 1
+# HELP cherrygo_requests_in_flight Number of Cherry Servers API calls in flight.
+# TYPE cherrygo_requests_in_flight gauge
+cherrygo_requests_in_flight{endpoint="/v1/servers/{server_id}",method="GET",operation="Servers.Get"} 0
+# HELP cherrygo_retries_total Total number of retried Cherry Servers API request attempts.
+# TYPE cherrygo_retries_total counter
+cherrygo_retries_total{endpoint="/v1/servers/{server_id}",method="GET",operation="Servers.Get"} 1
+# HELP cherrygo_poll_iterations_total Total number of polling iterations, by observed status.
+# TYPE cherrygo_poll_iterations_total counter
+cherrygo_poll_iterations_total{operation="Servers.WaitForStatus",status="deploying"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"cherrygo_requests_total",
+		"cherrygo_requests_in_flight",
+		"cherrygo_retries_total",
+		"cherrygo_poll_iterations_total",
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m, "cherrygo_request_duration_seconds"))
+}
@@ -0,0 +1,224 @@
+package cherrygo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// WithCoalescing enables coalescing of identical GET requests.
+//
+// While a GET request is in flight, identical requests made with the same
+// client, i.e. to the same URL with the same API key, don't send a request
+// of their own, but wait for the response of the one in flight. Every waiter
+// receives a copy of that response, so they decode the same result.
+// Coalesced responses have [Meta.Coalesced] set.
+//
+// Every caller can stop waiting by cancelling its own context, without
+// affecting the others. The shared request is cancelled once no caller
+// is waiting for it, or once the latest deadline of its callers passed.
+// Callers without a deadline allow it 5 minutes. It doesn't carry the values
+// of the context of any caller, so e.g. its attempts aren't traced as
+// children of a caller span.
+//
+// Calls that change the request or how it's retried, with the [Header],
+// [RequestID] or [NoRetries] call options or a context from
+// [ContextWithRetryPolicy] or [ContextWithoutRetries], aren't coalesced.
+func WithCoalescing() ClientOpt {
+	return func(c *options) error {
+		co := &coalescer{flights: make(map[string]*flight), timeout: defaultFlightTimeout}
+		c.clientOpts = append(c.clientOpts, client.WithCallMiddleware(co.middleware))
+		return nil
+	}
+}
+
+// defaultFlightTimeout limits shared requests of callers without a deadline.
+const defaultFlightTimeout = 5 * time.Minute
+
+type coalescer struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+
+	// timeout limits the flight for waiters without a deadline,
+	// if it's positive.
+	timeout time.Duration
+}
+
+// flight is a request in flight, shared by its waiters.
+type flight struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	// The fields below are guarded by coalescer.mu.
+	waiters int
+	// deadline is the latest deadline of the waiters, and timer cancels
+	// the flight once it passed. Both are unset for an unbounded flight.
+	deadline time.Time
+	timer    *time.Timer
+
+	// The fields below are written before done is closed.
+	stats client.Stats
+	resp  *http.Response
+	body  []byte
+	err   error
+}
+
+func (c *coalescer) middleware(next client.Doer) client.Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if !coalescable(req) {
+			return next.Do(req)
+		}
+
+		key := cacheKey(req)
+
+		c.mu.Lock()
+		f, joined := c.flights[key]
+		if !joined {
+			f = &flight{done: make(chan struct{})}
+
+			// The shared request must outlive the context of the caller that
+			// started it, so it is only cancelled when every waiter left or
+			// their deadlines passed, and it must not take on the settings
+			// of that caller.
+			ctx, cancel := context.WithCancel(context.Background())
+			f.cancel = cancel
+			c.flights[key] = f
+			c.extend(f, req.Context())
+			go c.run(key, f, next, req.WithContext(client.ContextWithStats(ctx, &f.stats)))
+		} else {
+			c.extend(f, req.Context())
+		}
+		f.waiters++
+		c.mu.Unlock()
+
+		select {
+		case <-f.done:
+			return f.result(req, joined)
+		case <-req.Context().Done():
+			c.leave(key, f)
+			return nil, req.Context().Err()
+		}
+	})
+}
+
+// coalescable reports whether req may share a flight with identical requests,
+// i.e. it's a GET request without settings of its own.
+func coalescable(req *http.Request) bool {
+	return req.Method == http.MethodGet &&
+		len(callOptionsFromContext(req.Context()).header) == 0 &&
+		!client.HasContextPolicy(req.Context())
+}
+
+// extend moves the deadline of f to the one of a waiter with ctx, if it's later.
+// The caller must hold c.mu.
+func (c *coalescer) extend(f *flight, ctx context.Context) {
+	if f.waiters > 0 && f.timer == nil {
+		return
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		if c.timeout <= 0 {
+			// The waiter may wait for as long as the request takes.
+			if f.timer != nil {
+				f.timer.Stop()
+				f.timer = nil
+			}
+			return
+		}
+		deadline = time.Now().Add(c.timeout)
+	}
+
+	switch {
+	case f.timer == nil:
+		f.timer = time.AfterFunc(time.Until(deadline), f.cancel)
+	case deadline.After(f.deadline):
+		f.timer.Reset(time.Until(deadline))
+	default:
+		return
+	}
+	f.deadline = deadline
+}
+
+func (c *coalescer) run(key string, f *flight, next client.Doer, req *http.Request) {
+	resp, err := next.Do(req)
+
+	// Buffer the body, so every waiter can read its own copy.
+	var retryErr *client.RetryError
+	if errors.As(err, &retryErr) && retryErr.Response != nil {
+		resp = retryErr.Response
+	}
+	if resp != nil {
+		body, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr != nil && err == nil {
+			resp, err = nil, readErr
+		}
+		f.body = body
+	}
+	f.resp, f.err = resp, err
+
+	c.mu.Lock()
+	if c.flights[key] == f {
+		delete(c.flights, key)
+	}
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	c.mu.Unlock()
+
+	f.cancel()
+	close(f.done)
+}
+
+// leave unregisters a waiter that stopped waiting,
+// cancelling the flight if it was the last one.
+func (c *coalescer) leave(key string, f *flight) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f.waiters--
+	if f.waiters == 0 {
+		if f.timer != nil {
+			f.timer.Stop()
+		}
+		f.cancel()
+		if c.flights[key] == f {
+			delete(c.flights, key)
+		}
+	}
+}
+
+// result returns a copy of the flight outcome for the waiter that made req.
+func (f *flight) result(req *http.Request, coalesced bool) (*http.Response, error) {
+	if stats := client.StatsFromContext(req.Context()); stats != nil {
+		*stats = f.stats
+		stats.Coalesced = coalesced
+	}
+
+	var resp *http.Response
+	if f.resp != nil {
+		resp = new(http.Response)
+		*resp = *f.resp
+		resp.Header = f.resp.Header.Clone()
+		resp.Body = io.NopCloser(bytes.NewReader(f.body))
+		resp.Request = req
+	}
+
+	var retryErr *client.RetryError
+	if errors.As(f.err, &retryErr) && retryErr.Response != nil {
+		errCopy := *retryErr
+		errCopy.Response = resp
+		return nil, &errCopy
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return resp, nil
+}
@@ -0,0 +1,294 @@
+package cherrygo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCoalescingClient returns a client with a coalescer the test can inspect.
+func newCoalescingClient(t *testing.T) (*Client, *coalescer) {
+	t.Helper()
+
+	co := &coalescer{flights: make(map[string]*flight)}
+	c, err := NewClient(WithURL(server.URL), func(o *options) error {
+		o.clientOpts = append(o.clientOpts, client.WithCallMiddleware(co.middleware))
+		return nil
+	})
+	require.NoError(t, err)
+	return c, co
+}
+
+// awaitWaiters blocks until n callers wait for the only flight.
+func awaitWaiters(t *testing.T, co *coalescer, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		co.mu.Lock()
+		defer co.mu.Unlock()
+		for _, f := range co.flights {
+			return f.waiters == n
+		}
+		return false
+	}, time.Second, time.Millisecond)
+}
+
+func TestCoalescing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, co := newCoalescingClient(t)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		<-release
+		_, err := fmt.Fprint(w, `{"id": 1, "hostname": "shared"}`)
+		require.NoError(t, err)
+	})
+
+	const n = 5
+	var wg sync.WaitGroup
+	results := make([]*Response, n)
+	servers := make([]Server, n)
+	for i := range n {
+		wg.Go(func() {
+			srv, resp, err := c.Servers.Get(t.Context(), 1, nil)
+			assert.NoError(t, err)
+			servers[i], results[i] = srv, resp
+		})
+	}
+
+	awaitWaiters(t, co, n)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	coalesced := 0
+	for i := range n {
+		assert.Equal(t, "shared", servers[i].Hostname)
+		assert.Equal(t, 1, results[i].Attempts)
+		if results[i].Coalesced {
+			coalesced++
+		}
+	}
+	assert.Equal(t, n-1, coalesced)
+
+	// Once the flight landed, requests are sent again.
+	_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+	assert.False(t, resp.Coalesced)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCoalescingSharesErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, co := newCoalescingClient(t)
+
+	release := make(chan struct{})
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNotFound)
+		_, err := fmt.Fprint(w, `{"code": 404, "message": "server not found"}`)
+		require.NoError(t, err)
+	})
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+			assert.ErrorContains(t, err, "server not found")
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	}
+
+	awaitWaiters(t, co, 2)
+	close(release)
+	wg.Wait()
+}
+
+func TestCoalescingCancellation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, co := newCoalescingClient(t)
+
+	release := make(chan struct{})
+	entered := make(chan struct{}, 2)
+	abandoned := make(chan struct{})
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			close(abandoned)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	// The caller that started the request leaves, the other one still gets the response.
+	leaderCtx, cancelLeader := context.WithCancel(t.Context())
+	leaderErr := make(chan error)
+	go func() {
+		_, _, err := c.Servers.Get(leaderCtx, 1, nil)
+		leaderErr <- err
+	}()
+	awaitWaiters(t, co, 1)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		srv, resp, err := c.Servers.Get(t.Context(), 1, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, srv.ID)
+		assert.True(t, resp.Coalesced)
+	})
+	awaitWaiters(t, co, 2)
+	<-entered
+
+	cancelLeader()
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+	close(release)
+	wg.Wait()
+
+	// The request is cancelled when every caller leaves.
+	release = make(chan struct{})
+	ctx, cancel := context.WithCancel(t.Context())
+	errc := make(chan error)
+	go func() {
+		_, _, err := c.Servers.Get(ctx, 1, nil)
+		errc <- err
+	}()
+	awaitWaiters(t, co, 1)
+	// Wait for the request to arrive, so cancelling abandons it rather than preventing it.
+	<-entered
+
+	cancel()
+	assert.ErrorIs(t, <-errc, context.Canceled)
+	select {
+	case <-abandoned:
+	case <-time.After(time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
+
+func TestCoalescingSkipsCallSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, co := newCoalescingClient(t)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	var requestIDs sync.Map
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		requestIDs.Store(r.Header.Get(RequestIDHeader), true)
+		<-release
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+		assert.NoError(t, err)
+		assert.False(t, resp.Coalesced)
+	})
+	awaitWaiters(t, co, 1)
+
+	cases := []struct {
+		title string
+		ctx   context.Context
+		opts  []CallOption
+	}{
+		{title: "request ID", ctx: t.Context(), opts: []CallOption{RequestID("req-1")}},
+		{title: "header", ctx: t.Context(), opts: []CallOption{Header("X-Debug", "1")}},
+		{title: "no retries", ctx: t.Context(), opts: []CallOption{NoRetries()}},
+		{title: "retry policy", ctx: ContextWithRetryPolicy(t.Context(), RetryPolicy{Backoff: testRetryBackoff})},
+	}
+	for _, tc := range cases {
+		wg.Go(func() {
+			_, resp, err := c.Servers.Get(tc.ctx, 1, nil, tc.opts...)
+			assert.NoError(t, err, tc.title)
+			assert.False(t, resp.Coalesced, tc.title)
+		})
+	}
+
+	assert.Eventually(t, func() bool {
+		return calls.Load() == int32(1+len(cases))
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	_, ok := requestIDs.Load("req-1")
+	assert.True(t, ok, "Calls with a request ID should send it.")
+}
+
+func TestCoalescingFlightTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, co := newCoalescingClient(t)
+	co.timeout = 50 * time.Millisecond
+
+	cancelled := make(chan struct{})
+	mux.HandleFunc("/v1/servers/1", func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	})
+
+	_, _, err := c.Servers.Get(t.Context(), 1, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared request wasn't cancelled")
+	}
+}
+
+func TestCoalescingFlightDeadlineExtended(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, co := newCoalescingClient(t)
+	co.timeout = 50 * time.Millisecond
+
+	entered := make(chan struct{})
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		close(entered)
+		time.Sleep(200 * time.Millisecond)
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, _, err := c.Servers.Get(ctx, 1, nil)
+		assert.NoError(t, err)
+	})
+	<-entered
+
+	// The shorter timeout of a later waiter doesn't cut the flight short.
+	_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+	assert.True(t, resp.Coalesced)
+	wg.Wait()
+}
@@ -0,0 +1,370 @@
+package cherrygo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/backoff"
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	cherryProfileVar = "CHERRY_PROFILE"
+	cherryConfigVar  = "CHERRY_CONFIG"
+)
+
+// apiKeyCommandTimeout limits how long the API key command of a profile
+// may take when the client is created.
+var apiKeyCommandTimeout = 30 * time.Second
+
+// configFileNames are the file names looked for in the configuration directory, in order.
+var configFileNames = []string{"config.yaml", "config.yml", "config.json"}
+
+// Config is a configuration file, holding named profiles.
+//
+// It is YAML, or JSON, which is a subset of YAML:
+//
+//	current_profile: staging
+//	profiles:
+//	  staging:
+//	    api_key_command: ["pass", "show", "cherry/staging"]
+//	    api_url: https://api.staging.example.com/v1/
+//	    project_id: 321
+//	    region: eu_nord_1
+//	    retry:
+//	      max_retries: 3
+//	      backoff_cap: 10s
+type Config struct {
+	// CurrentProfile is the profile used when none is selected
+	// with [WithProfile] or the CHERRY_PROFILE environment variable.
+	// It only applies to files set with [WithConfigFile] or the CHERRY_CONFIG
+	// environment variable, since the default file is only read for a selected profile.
+	CurrentProfile string `yaml:"current_profile"`
+
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile is a named set of client settings.
+type Profile struct {
+	// APIKey is the API key. Takes precedence over APIKeyCommand.
+	APIKey string `yaml:"api_key"`
+
+	// APIKeyCommand is a command, with its arguments, that prints the API key,
+	// e.g. to read it from a password manager. It is not run by a shell.
+	// It is run again when the API rejects the key, see [ExecCredentials].
+	// When the client is created, it must print the key within 30 seconds.
+	APIKeyCommand []string `yaml:"api_key_command"`
+
+	// APIURL is the base URL of the API.
+	APIURL string `yaml:"api_url"`
+
+	// UserAgent is prepended to the client user agent, like with [WithUserAgent].
+	UserAgent string `yaml:"user_agent"`
+
+	// TeamID, ProjectID and Region are the client [Defaults].
+	TeamID    int    `yaml:"team_id"`
+	ProjectID int    `yaml:"project_id"`
+	Region    string `yaml:"region"`
+
+	Retry *ProfileRetry `yaml:"retry"`
+}
+
+// ProfileRetry are the retry settings of a profile.
+// Unset settings keep their defaults.
+type ProfileRetry struct {
+	MaxRetries            *int          `yaml:"max_retries"`
+	BackoffBase           time.Duration `yaml:"backoff_base"`
+	BackoffCap            time.Duration `yaml:"backoff_cap"`
+	RetryableStatuses     []int         `yaml:"retryable_statuses"`
+	RetryConnectionErrors *bool         `yaml:"retry_connection_errors"`
+}
+
+// Defaults are the team, project and region used when a request leaves them unset.
+//
+// Zero team and project IDs of the Projects, Servers, Storages and IPAddresses
+// List and Create methods are replaced by the defaults, as are empty regions
+// of [CreateServer] and [CreateStorage] requests.
+type Defaults struct {
+	TeamID    int
+	ProjectID int
+	Region    string
+}
+
+func (c *Client) teamID(id int) int {
+	if id == 0 {
+		return c.Defaults.TeamID
+	}
+	return id
+}
+
+func (c *Client) projectID(id int) int {
+	if id == 0 {
+		return c.Defaults.ProjectID
+	}
+	return id
+}
+
+// applyToServer returns a copy of request with the defaults applied,
+// or request itself if there is nothing to apply.
+func (d Defaults) applyToServer(request *CreateServer) *CreateServer {
+	if request == nil || (request.ProjectID != 0 || d.ProjectID == 0) && (request.Region != "" || d.Region == "") {
+		return request
+	}
+
+	applied := *request
+	if applied.ProjectID == 0 {
+		applied.ProjectID = d.ProjectID
+	}
+	if applied.Region == "" {
+		applied.Region = d.Region
+	}
+	return &applied
+}
+
+func (d Defaults) applyToStorage(request *CreateStorage) *CreateStorage {
+	if request == nil || request.Region != "" || d.Region == "" {
+		return request
+	}
+
+	applied := *request
+	applied.Region = d.Region
+	return &applied
+}
+
+// DefaultConfigPath returns the path of the configuration file that is read
+// when none is set with [WithConfigFile] or the CHERRY_CONFIG environment variable,
+// i.e. the first existing config.yaml, config.yml or config.json file
+// in $XDG_CONFIG_HOME/cherry, or ~/.config/cherry if XDG_CONFIG_HOME is unset.
+// If none exists, the path of config.yaml is returned.
+func DefaultConfigPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	dir = filepath.Join(dir, "cherry")
+
+	for _, name := range configFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return filepath.Join(dir, configFileNames[0]), nil
+}
+
+// LoadConfig reads a configuration file. Unknown settings are an error.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// WithProfile selects the configuration file profile to read settings from.
+// Defaults to the CHERRY_PROFILE environment variable, or else the
+// current profile of a configuration file set with [WithConfigFile].
+//
+// Settings are resolved in order of precedence:
+//
+//  1. Explicit options, e.g. [WithAPIKey].
+//  2. Environment variables, e.g. CHERRY_API_KEY.
+//  3. The profile.
+//
+// Selecting a profile that doesn't exist is an error.
+func WithProfile(name string) ClientOpt {
+	return func(c *options) error {
+		c.profile = name
+		return nil
+	}
+}
+
+// WithConfigFile sets the configuration file to read profiles from.
+// Defaults to the CHERRY_CONFIG environment variable, or else [DefaultConfigPath].
+// The default file is only read when a profile is selected, see [WithProfile],
+// while a file set explicitly is always read and must exist.
+func WithConfigFile(path string) ClientOpt {
+	return func(c *options) error {
+		c.configPath = path
+		return nil
+	}
+}
+
+// WithDefaults sets the default team, project and region.
+// Zero fields fall back to the profile, see [WithProfile].
+func WithDefaults(d Defaults) ClientOpt {
+	return func(c *options) error {
+		c.defaults = d
+		return nil
+	}
+}
+
+// resolve fills in the settings that were not set with options,
+// from the environment, the profile and finally the defaults.
+func (o *options) resolve() error {
+	if o.apiKey == "" && o.credentials == nil {
+		o.apiKey = os.Getenv(cherryAPIKeyVar)
+	}
+
+	profile, err := o.loadProfile()
+	if err != nil {
+		return err
+	}
+	if profile != nil {
+		if err := o.applyProfile(profile); err != nil {
+			return err
+		}
+	}
+
+	if o.url == "" {
+		o.url = apiURL
+	}
+	if o.userAgent == "" {
+		o.userAgent = userAgent
+	}
+	return nil
+}
+
+// loadProfile returns the selected profile, or nil if there is none.
+// The default configuration file is only read when a profile is selected,
+// so a broken file doesn't break clients that don't use it.
+func (o *options) loadProfile() (*Profile, error) {
+	name := o.profile
+	if name == "" {
+		name = os.Getenv(cherryProfileVar)
+	}
+
+	path := o.configPath
+	if path == "" {
+		path = os.Getenv(cherryConfigVar)
+	}
+	if path == "" {
+		if name == "" {
+			return nil, nil
+		}
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, fmt.Errorf("failed to locate config file: %w", err)
+		}
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = cfg.CurrentProfile
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in config file %s", name, path)
+	}
+	return &profile, nil
+}
+
+func (o *options) applyProfile(p *Profile) error {
+	if o.apiKey == "" && o.credentials == nil {
+		o.apiKey = p.APIKey
+	}
+	if o.apiKey == "" && o.credentials == nil && len(p.APIKeyCommand) > 0 {
+		creds := ExecCredentials(p.APIKeyCommand, 0)
+		// Run the command right away, so a broken one fails early.
+		ctx, cancel := context.WithTimeout(context.Background(), apiKeyCommandTimeout)
+		defer cancel()
+		if _, err := creds.Credentials(ctx); err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("api key command %q didn't finish within %v", p.APIKeyCommand[0], apiKeyCommandTimeout)
+			}
+			return err
+		}
+		o.credentials = creds
+	}
+
+	if o.url == "" {
+		o.url = p.APIURL
+	}
+	if o.userAgent == "" && p.UserAgent != "" {
+		o.userAgent = fmt.Sprintf("%s %s", p.UserAgent, userAgent)
+	}
+
+	if o.defaults.TeamID == 0 {
+		o.defaults.TeamID = p.TeamID
+	}
+	if o.defaults.ProjectID == 0 {
+		o.defaults.ProjectID = p.ProjectID
+	}
+	if o.defaults.Region == "" {
+		o.defaults.Region = p.Region
+	}
+
+	if p.Retry != nil {
+		retryOpts, err := p.Retry.clientOptions()
+		if err != nil {
+			return err
+		}
+		// Options applied later take precedence,
+		// so explicit retry options override the profile.
+		o.clientOpts = append(retryOpts, o.clientOpts...)
+	}
+	return nil
+}
+
+func (r *ProfileRetry) clientOptions() ([]client.Option, error) {
+	var opts []client.Option
+
+	if r.MaxRetries != nil {
+		if *r.MaxRetries < 0 {
+			return nil, fmt.Errorf("profile max retries must not be negative, got %d", *r.MaxRetries)
+		}
+		opts = append(opts, client.WithMaxRetries(*r.MaxRetries))
+	}
+
+	if r.BackoffBase != 0 || r.BackoffCap != 0 {
+		cfg := backoff.ExponentialBackoffConfig{
+			Base:       time.Second,
+			Cap:        30 * time.Second,
+			Multiplier: 2,
+		}
+		if r.BackoffBase != 0 {
+			cfg.Base = r.BackoffBase
+		}
+		if r.BackoffCap != 0 {
+			cfg.Cap = r.BackoffCap
+		}
+		if cfg.Base < 0 || cfg.Cap < cfg.Base {
+			return nil, fmt.Errorf("profile backoff base must be positive and not exceed the cap, got %v and %v", cfg.Base, cfg.Cap)
+		}
+		opts = append(opts, client.WithBackoff(backoff.RateLimitedExponentialBackoff(cfg)))
+	}
+
+	if r.RetryableStatuses != nil {
+		opts = append(opts, client.WithRetryableStatuses(r.RetryableStatuses))
+	}
+	if r.RetryConnectionErrors != nil {
+		opts = append(opts, client.WithRetryConnectionErrors(*r.RetryConnectionErrors))
+	}
+	return opts, nil
+}
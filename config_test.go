@@ -0,0 +1,324 @@
+package cherrygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolateConfig clears the configuration environment and
+// points the default configuration directory to an empty one.
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(cherryAPIKeyVar, "")
+	t.Setenv(cherryProfileVar, "")
+	t.Setenv(cherryConfigVar, "")
+	return dir
+}
+
+func writeConfig(t *testing.T, path, content string) string {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+const testConfig = `
+current_profile: prod
+profiles:
+  prod:
+    api_key: prod-key
+    api_url: https://prod.example.com/v1/
+  staging:
+    api_key: staging-key
+    api_url: https://staging.example.com/v1/
+    user_agent: deployer
+    team_id: 123
+    project_id: 321
+    region: eu_nord_1
+    retry:
+      max_retries: 2
+      backoff_cap: 10s
+`
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("yaml", func(t *testing.T) {
+		cfg, err := LoadConfig(writeConfig(t, filepath.Join(dir, "config.yaml"), testConfig))
+		require.NoError(t, err)
+
+		assert.Equal(t, "prod", cfg.CurrentProfile)
+		staging := cfg.Profiles["staging"]
+		assert.Equal(t, "staging-key", staging.APIKey)
+		assert.Equal(t, 321, staging.ProjectID)
+		require.NotNil(t, staging.Retry)
+		assert.Equal(t, 2, *staging.Retry.MaxRetries)
+		assert.Equal(t, 10*time.Second, staging.Retry.BackoffCap)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cfg, err := LoadConfig(writeConfig(t, filepath.Join(dir, "config.json"), `{
+			"profiles": {"dev": {"api_key_command": ["pass", "cherry"], "retry": {"retryable_statuses": [503]}}}
+		}`))
+		require.NoError(t, err)
+
+		dev := cfg.Profiles["dev"]
+		assert.Equal(t, []string{"pass", "cherry"}, dev.APIKeyCommand)
+		assert.Equal(t, []int{503}, dev.Retry.RetryableStatuses)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		cfg, err := LoadConfig(writeConfig(t, filepath.Join(dir, "empty.yaml"), ""))
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Profiles)
+	})
+
+	t.Run("unknown setting", func(t *testing.T) {
+		_, err := LoadConfig(writeConfig(t, filepath.Join(dir, "typo.yaml"), "profiles:\n  dev:\n    api_kee: key\n"))
+		assert.ErrorContains(t, err, "api_kee")
+	})
+}
+
+func TestDefaultConfigPath(t *testing.T) {
+	dir := isolateConfig(t)
+
+	path, err := DefaultConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "cherry", "config.yaml"), path)
+
+	jsonPath := writeConfig(t, filepath.Join(dir, "cherry", "config.json"), "{}")
+	path, err = DefaultConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, jsonPath, path)
+}
+
+func TestNewClientProfile(t *testing.T) {
+	dir := isolateConfig(t)
+	writeConfig(t, filepath.Join(dir, "cherry", "config.yaml"), testConfig)
+
+	t.Run("current profile of default file", func(t *testing.T) {
+		c, err := NewClient(WithAPIKey("key"))
+		require.NoError(t, err)
+		assert.Equal(t, "key", c.APIKey)
+		assert.Equal(t, apiURL, c.BaseURL.String())
+	})
+
+	t.Run("current profile", func(t *testing.T) {
+		t.Setenv(cherryConfigVar, filepath.Join(dir, "cherry", "config.yaml"))
+
+		c, err := NewClient()
+		require.NoError(t, err)
+		assert.Equal(t, "prod-key", c.APIKey)
+		assert.Equal(t, "https://prod.example.com/v1/", c.BaseURL.String())
+		assert.Equal(t, userAgent, c.UserAgent)
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(cherryProfileVar, "staging")
+
+		c, err := NewClient()
+		require.NoError(t, err)
+		assert.Equal(t, "staging-key", c.APIKey)
+		assert.Equal(t, "deployer "+userAgent, c.UserAgent)
+		assert.Equal(t, Defaults{TeamID: 123, ProjectID: 321, Region: "eu_nord_1"}, c.Defaults)
+	})
+
+	t.Run("option", func(t *testing.T) {
+		t.Setenv(cherryProfileVar, "staging")
+
+		c, err := NewClient(WithProfile("prod"))
+		require.NoError(t, err)
+		assert.Equal(t, "prod-key", c.APIKey)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := NewClient(WithProfile("dev"))
+		assert.ErrorContains(t, err, `profile "dev" not found`)
+	})
+}
+
+func TestNewClientPrecedence(t *testing.T) {
+	isolateConfig(t)
+	path := writeConfig(t, filepath.Join(t.TempDir(), "cherry.yaml"), testConfig)
+	t.Setenv(cherryConfigVar, path)
+
+	c, err := NewClient(WithProfile("staging"))
+	require.NoError(t, err)
+	assert.Equal(t, "staging-key", c.APIKey)
+
+	t.Setenv(cherryAPIKeyVar, "env-key")
+	c, err = NewClient(WithProfile("staging"))
+	require.NoError(t, err)
+	assert.Equal(t, "env-key", c.APIKey)
+
+	c, err = NewClient(
+		WithProfile("staging"),
+		WithAPIKey("explicit-key"),
+		WithURL("https://explicit.example.com/v1/"),
+		WithUserAgent("explicit"),
+		WithDefaults(Defaults{ProjectID: 1}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "explicit-key", c.APIKey)
+	assert.Equal(t, "https://explicit.example.com/v1/", c.BaseURL.String())
+	assert.Equal(t, "explicit "+userAgent, c.UserAgent)
+	assert.Equal(t, Defaults{TeamID: 123, ProjectID: 1, Region: "eu_nord_1"}, c.Defaults)
+}
+
+func TestNewClientConfigFile(t *testing.T) {
+	isolateConfig(t)
+
+	t.Run("no default file", func(t *testing.T) {
+		c, err := NewClient(WithAPIKey("key"))
+		require.NoError(t, err)
+		assert.Equal(t, apiURL, c.BaseURL.String())
+	})
+
+	t.Run("broken default file", func(t *testing.T) {
+		dir := isolateConfig(t)
+		writeConfig(t, filepath.Join(dir, "cherry", "config.yaml"), "profiles:\n  dev:\n    api_kee: key\n")
+
+		c, err := NewClient(WithAPIKey("key"))
+		require.NoError(t, err)
+		assert.Equal(t, "key", c.APIKey)
+
+		_, err = NewClient(WithProfile("dev"))
+		assert.ErrorContains(t, err, "api_kee")
+	})
+
+	t.Run("missing default file", func(t *testing.T) {
+		isolateConfig(t)
+		_, err := NewClient(WithAPIKey("key"), WithProfile("dev"))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing explicit file", func(t *testing.T) {
+		_, err := NewClient(WithAPIKey("key"), WithConfigFile(filepath.Join(t.TempDir(), "missing.yaml")))
+		assert.Error(t, err)
+	})
+
+	t.Run("no profile", func(t *testing.T) {
+		path := writeConfig(t, filepath.Join(t.TempDir(), "config.yaml"), "profiles:\n  dev:\n    api_key: dev-key\n")
+		c, err := NewClient(WithAPIKey("key"), WithConfigFile(path))
+		require.NoError(t, err)
+		assert.Equal(t, "key", c.APIKey)
+	})
+}
+
+func TestProfileAPIKeyCommand(t *testing.T) {
+	isolateConfig(t)
+	dir := t.TempDir()
+
+	path := writeConfig(t, filepath.Join(dir, "config.yaml"), `
+profiles:
+  dev:
+    api_key_command: ["echo", "command-key"]
+  broken:
+    api_key_command: ["false"]
+  slow:
+    api_key_command: ["sleep", "10"]
+`)
+
+	c, err := NewClient(WithConfigFile(path), WithProfile("dev"))
+	require.NoError(t, err)
+	identity, err := c.CredentialsIdentity(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "command-key"}.Identity(), identity)
+
+	_, err = NewClient(WithConfigFile(path), WithProfile("broken"))
+	assert.ErrorContains(t, err, "api key command failed")
+
+	defer func(d time.Duration) { apiKeyCommandTimeout = d }(apiKeyCommandTimeout)
+	apiKeyCommandTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err = NewClient(WithConfigFile(path), WithProfile("slow"))
+	assert.ErrorContains(t, err, `api key command "sleep" didn't finish within 50ms`)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestProfileRetry(t *testing.T) {
+	setup()
+	defer teardown()
+	isolateConfig(t)
+
+	path := writeConfig(t, filepath.Join(t.TempDir(), "config.yaml"), fmt.Sprintf(`
+profiles:
+  dev:
+    api_key: key
+    api_url: %s/v1/
+    retry:
+      max_retries: 1
+      backoff_base: 1ms
+      backoff_cap: 1ms
+`, server.URL))
+
+	attempts := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	c, err := NewClient(WithConfigFile(path), WithProfile("dev"))
+	require.NoError(t, err)
+	_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+	require.Error(t, err)
+	assert.Equal(t, 2, resp.Attempts)
+
+	c, err = NewClient(WithConfigFile(path), WithProfile("dev"), WithMaxRetries(0))
+	require.NoError(t, err)
+	_, resp, err = c.Servers.Get(t.Context(), 1, nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, resp.Attempts)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDefaults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithDefaults(Defaults{TeamID: 1, ProjectID: 2, Region: "eu_nord_1"}))
+	require.NoError(t, err)
+
+	mux.HandleFunc("GET /v1/teams/1/projects", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `[]`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("POST /v1/projects/2/servers", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "eu_nord_1", body["region"])
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("POST /v1/projects/3/storages", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "eu_west_1", body["region"])
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Projects.List(t.Context(), 0, nil)
+	require.NoError(t, err)
+
+	request := &CreateServer{Plan: "cloud_vps_1"}
+	_, _, err = c.Servers.Create(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, &CreateServer{Plan: "cloud_vps_1"}, request, "request must not be modified")
+
+	_, _, err = c.Storages.Create(t.Context(), 3, &CreateStorage{Size: 1, Region: "eu_west_1"})
+	require.NoError(t, err)
+}
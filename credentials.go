@@ -0,0 +1,325 @@
+package cherrygo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// Credentials authenticate requests to the API.
+type Credentials struct {
+	APIKey string
+}
+
+// Identity returns a fingerprint of the API key, that tells keys apart
+// without revealing them, e.g. for logging.
+func (c Credentials) Identity() string {
+	sum := sha256.Sum256([]byte(c.APIKey))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// CredentialsProvider provides the credentials of every request.
+// Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	// Credentials returns the current credentials. It is called for every
+	// request, so it should be cheap, e.g. by caching the credentials.
+	Credentials(ctx context.Context) (Credentials, error)
+
+	// Refresh discards any cached credentials and returns fresh ones.
+	// It is called when the API rejects the current credentials.
+	Refresh(ctx context.Context) (Credentials, error)
+}
+
+// WithCredentialsProvider makes the client authenticate with credentials from p,
+// which allows rotating API keys while the client is in use.
+// Takes precedence over API keys set with [WithAPIKey], the environment
+// or a profile.
+//
+// When the API rejects a request with 401 Unauthorized, the credentials are
+// refreshed and, if they changed, the request is sent once more.
+// Requests rejected at the same time share a single refresh.
+func WithCredentialsProvider(p CredentialsProvider) ClientOpt {
+	return func(c *options) error {
+		if p == nil {
+			return errors.New("credentials provider must not be nil")
+		}
+		c.credentials = p
+		return nil
+	}
+}
+
+// CredentialsIdentity returns the identity of the credentials
+// the next request will be sent with. See [Credentials.Identity].
+func (c *Client) CredentialsIdentity(ctx context.Context) (string, error) {
+	key, err := c.apiKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return Credentials{APIKey: key}.Identity(), nil
+}
+
+func (c *Client) apiKey(ctx context.Context) (string, error) {
+	if c.credentials == nil {
+		return c.APIKey, nil
+	}
+
+	creds, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get credentials: %w", err)
+	}
+	return creds.APIKey, nil
+}
+
+// refreshCredentials is a call middleware that sends requests rejected
+// with 401 Unauthorized once more, if refreshing the credentials changed them.
+func (c *Client) refreshCredentials(next client.Doer) client.Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		generation := c.refresher.current()
+		resp, err := next.Do(req)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		ctx := req.Context()
+		creds, rErr := c.refresher.refresh(ctx, generation)
+		if rErr != nil {
+			c.logger.WarnContext(ctx, "failed to refresh credentials", "error", rErr)
+			return resp, nil
+		}
+
+		bearer := "Bearer " + creds.APIKey
+		if bearer == req.Header.Get("Authorization") || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+
+		retry := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, bErr := req.GetBody()
+			if bErr != nil {
+				return resp, nil
+			}
+			retry.Body = body
+		}
+		retry.Header.Set("Authorization", bearer)
+
+		_ = resp.Body.Close()
+		c.logger.InfoContext(ctx, "credentials refreshed", "credentials", creds.Identity())
+		return next.Do(retry)
+	})
+}
+
+// credentialsRefresher refreshes the credentials of a provider once for
+// requests that were rejected together, instead of once per request.
+type credentialsRefresher struct {
+	provider CredentialsProvider
+
+	mu sync.Mutex
+	// generation counts the refreshes, creds and err are the outcome of the last one.
+	generation uint64
+	creds      Credentials
+	err        error
+}
+
+// current returns the generation of the credentials, to be passed
+// to refresh if the request sent with them is rejected.
+func (r *credentialsRefresher) current() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.generation
+}
+
+// refresh refreshes the credentials, unless they were refreshed since generation,
+// in which case the outcome of that refresh is returned.
+func (r *credentialsRefresher) refresh(ctx context.Context, generation uint64) (Credentials, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if generation != r.generation {
+		return r.creds, r.err
+	}
+
+	creds, err := r.provider.Refresh(ctx)
+	if err != nil && ctx.Err() != nil {
+		// The refresh failed because of this request, so the
+		// next rejected request may try again.
+		return creds, err
+	}
+	r.generation++
+	r.creds, r.err = creds, err
+	return creds, err
+}
+
+// authorizationIdentity returns the identity of the credentials of an Authorization header.
+func authorizationIdentity(header string) string {
+	return Credentials{APIKey: strings.TrimPrefix(header, "Bearer ")}.Identity()
+}
+
+// StaticCredentials returns a provider of a fixed API key.
+func StaticCredentials(apiKey string) CredentialsProvider {
+	return staticCredentials{Credentials{APIKey: apiKey}}
+}
+
+type staticCredentials struct {
+	creds Credentials
+}
+
+func (s staticCredentials) Credentials(context.Context) (Credentials, error) {
+	if s.creds.APIKey == "" {
+		return Credentials{}, errors.New("api key is empty")
+	}
+	return s.creds, nil
+}
+
+func (s staticCredentials) Refresh(ctx context.Context) (Credentials, error) {
+	return s.Credentials(ctx)
+}
+
+// EnvCredentials returns a provider that reads the API key from
+// the environment variable name on every request.
+func EnvCredentials(name string) CredentialsProvider {
+	return envCredentials(name)
+}
+
+type envCredentials string
+
+func (e envCredentials) Credentials(context.Context) (Credentials, error) {
+	key := os.Getenv(string(e))
+	if key == "" {
+		return Credentials{}, fmt.Errorf("environment variable %s is empty", string(e))
+	}
+	return Credentials{APIKey: key}, nil
+}
+
+func (e envCredentials) Refresh(ctx context.Context) (Credentials, error) {
+	return e.Credentials(ctx)
+}
+
+// FileCredentials returns a provider that reads the API key from the file at path.
+// The file is read again whenever its modification time or size changes,
+// so the key can be rotated by replacing the file.
+func FileCredentials(path string) CredentialsProvider {
+	return &fileCredentials{path: path}
+}
+
+type fileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	creds   Credentials
+	modTime time.Time
+	size    int64
+}
+
+func (f *fileCredentials) Credentials(context.Context) (Credentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if f.creds.APIKey != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.creds, nil
+	}
+	return f.read(info)
+}
+
+func (f *fileCredentials) Refresh(context.Context) (Credentials, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return f.read(info)
+}
+
+func (f *fileCredentials) read(info os.FileInfo) (Credentials, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return Credentials{}, fmt.Errorf("credentials file %s is empty", f.path)
+	}
+
+	f.creds = Credentials{APIKey: key}
+	f.modTime, f.size = info.ModTime(), info.Size()
+	return f.creds, nil
+}
+
+// ExecCredentials returns a provider that runs command, with its arguments,
+// which must print the API key, e.g. to read it from a password manager.
+// The command is not run by a shell.
+//
+// The key is cached for ttl, or until it is refreshed if ttl is zero.
+func ExecCredentials(command []string, ttl time.Duration) CredentialsProvider {
+	return &execCredentials{command: command, ttl: ttl, now: time.Now}
+}
+
+type execCredentials struct {
+	command []string
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	creds   Credentials
+	expires time.Time
+}
+
+func (e *execCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.creds.APIKey != "" && (e.ttl <= 0 || e.now().Before(e.expires)) {
+		return e.creds, nil
+	}
+	return e.run(ctx)
+}
+
+func (e *execCredentials) Refresh(ctx context.Context) (Credentials, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.run(ctx)
+}
+
+func (e *execCredentials) run(ctx context.Context) (Credentials, error) {
+	if len(e.command) == 0 {
+		return Credentials{}, errors.New("api key command is empty")
+	}
+
+	cmd := exec.CommandContext(ctx, e.command[0], e.command[1:]...)
+	// Don't wait for children of a killed command that hold on to its output.
+	cmd.WaitDelay = time.Second
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return Credentials{}, fmt.Errorf("api key command failed: %w: %s", err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return Credentials{}, fmt.Errorf("api key command failed: %w", err)
+	}
+
+	key := strings.TrimSpace(string(out))
+	if key == "" {
+		return Credentials{}, errors.New("api key command printed nothing")
+	}
+
+	e.creds = Credentials{APIKey: key}
+	e.expires = e.now().Add(e.ttl)
+	return e.creds, nil
+}
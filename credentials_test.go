@@ -0,0 +1,231 @@
+package cherrygo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingCredentials serves keys in order, moving to the next one on refresh.
+type rotatingCredentials struct {
+	mu        sync.Mutex
+	keys      []string
+	refreshes int
+}
+
+func (r *rotatingCredentials) Credentials(context.Context) (Credentials, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Credentials{APIKey: r.keys[min(r.refreshes, len(r.keys)-1)]}, nil
+}
+
+func (r *rotatingCredentials) Refresh(ctx context.Context) (Credentials, error) {
+	r.mu.Lock()
+	r.refreshes++
+	r.mu.Unlock()
+	return r.Credentials(ctx)
+}
+
+func TestCredentialsRefresh(t *testing.T) {
+	setup()
+	defer teardown()
+
+	creds := &rotatingCredentials{keys: []string{"revoked", "fresh"}}
+	c, err := NewClient(WithURL(server.URL), WithAPIKey("ignored"), WithCredentialsProvider(creds))
+	require.NoError(t, err)
+
+	var bodies []string
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body["hostname"].(string))
+
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	srv, resp, err := c.Servers.Create(t.Context(), &CreateServer{ProjectID: 1, Hostname: "web"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, srv.ID)
+	assert.Equal(t, 2, resp.Attempts)
+	assert.Equal(t, []string{"web", "web"}, bodies)
+	assert.Equal(t, 1, creds.refreshes)
+
+	identity, err := c.CredentialsIdentity(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, Credentials{APIKey: "fresh"}.Identity(), identity)
+}
+
+func TestCredentialsRefreshConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	creds := &rotatingCredentials{keys: []string{"revoked", "fresh"}}
+	c, err := NewClient(WithURL(server.URL), WithCredentialsProvider(creds))
+	require.NoError(t, err)
+
+	const n = 5
+	var rejected sync.WaitGroup
+	rejected.Add(n)
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			// Reject once every request was sent with the revoked key.
+			rejected.Done()
+			rejected.Wait()
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	var wg sync.WaitGroup
+	for range n {
+		wg.Go(func() {
+			srv, _, err := c.Servers.Get(t.Context(), 1, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, srv.ID)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, creds.refreshes)
+}
+
+func TestCredentialsRefreshUnchanged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithCredentialsProvider(StaticCredentials("revoked")))
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestCredentialsLogging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := NewClient(WithURL(server.URL), WithLogger(logger), WithCredentialsProvider(StaticCredentials("secret-key")))
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret-key", r.Header.Get("Authorization"))
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "secret-key")
+	records := logRecords(t, buf)
+	require.NotEmpty(t, records)
+	assert.Equal(t, Credentials{APIKey: "secret-key"}.Identity(), records[0]["credentials"])
+}
+
+func TestCredentialsIdentity(t *testing.T) {
+	a := Credentials{APIKey: "a"}.Identity()
+	assert.Equal(t, a, Credentials{APIKey: "a"}.Identity())
+	assert.NotEqual(t, a, Credentials{APIKey: "b"}.Identity())
+	assert.Regexp(t, `^sha256:[0-9a-f]{12}$`, a)
+}
+
+func TestStaticCredentials(t *testing.T) {
+	creds, err := StaticCredentials("key").Credentials(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "key", creds.APIKey)
+
+	_, err = StaticCredentials("").Credentials(t.Context())
+	assert.Error(t, err)
+}
+
+func TestEnvCredentials(t *testing.T) {
+	p := EnvCredentials("CHERRY_TEST_KEY")
+
+	t.Setenv("CHERRY_TEST_KEY", "")
+	_, err := p.Credentials(t.Context())
+	assert.Error(t, err)
+
+	t.Setenv("CHERRY_TEST_KEY", "rotated")
+	creds, err := p.Credentials(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", creds.APIKey)
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+
+	p := FileCredentials(path)
+	creds, err := p.Credentials(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "first", creds.APIKey)
+
+	// Replace the file, moving its modification time so the change is seen
+	// regardless of the file system timestamp resolution.
+	require.NoError(t, os.WriteFile(path, []byte("second\n"), 0o600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	creds, err = p.Credentials(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "second", creds.APIKey)
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+	_, err = p.Refresh(t.Context())
+	assert.Error(t, err)
+}
+
+func TestExecCredentials(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "counter")
+	command := []string{"sh", "-c", `echo x >> "$0"; echo "key-$(wc -l < "$0" | tr -d ' ')"`, counter}
+
+	p := ExecCredentials(command, time.Minute).(*execCredentials)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	for range 2 {
+		creds, err := p.Credentials(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "key-1", creds.APIKey)
+	}
+
+	creds, err := p.Refresh(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", creds.APIKey)
+
+	now = now.Add(time.Minute)
+	creds, err = p.Credentials(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "key-3", creds.APIKey)
+
+	_, err = ExecCredentials([]string{"false"}, 0).Credentials(t.Context())
+	assert.ErrorContains(t, err, "api key command failed")
+}
@@ -0,0 +1,132 @@
+package cherrygo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/redact"
+)
+
+// WithDryRun makes every mutating call of the client behave as if it
+// had the [DryRun] call option: the request is built, validated as with
+// [WithValidation] and logged at info level, but not sent, and a synthetic
+// response with [Meta.DryRun] is returned.
+// Calls that only read are sent as usual, so lookups still work.
+//
+// If plan isn't nil, the requests that weren't sent are recorded in it,
+// including those of calls with the [DryRun] call option.
+func WithDryRun(plan *DryRunPlan) ClientOpt {
+	return func(c *options) error {
+		c.dryRun = true
+		c.plan = plan
+		return nil
+	}
+}
+
+// DryRunPlan records the requests a client didn't send because of a dry run.
+// It's safe for concurrent use. The zero value is an empty plan.
+type DryRunPlan struct {
+	mu    sync.Mutex
+	steps []DryRunStep
+}
+
+// DryRunStep is a request that would have been sent.
+type DryRunStep struct {
+	// Operation is the name of the service method, e.g. "Servers.Delete".
+	Operation string
+
+	Method string
+	URL    string
+
+	// Body is the JSON request body, with secrets such as passwords masked,
+	// or nil if the request has none.
+	Body json.RawMessage
+
+	Time time.Time
+}
+
+// String formats the step as a single line, e.g.
+//
+//	Servers.Delete: DELETE https://api.cherryservers.com/v1/servers/1
+func (s DryRunStep) String() string {
+	line := fmt.Sprintf("%s: %s %s", s.Operation, s.Method, s.URL)
+	if len(s.Body) > 0 {
+		line += " " + string(s.Body)
+	}
+	return line
+}
+
+// Steps returns the recorded steps, in the order the calls were made.
+func (p *DryRunPlan) Steps() []DryRunStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]DryRunStep(nil), p.steps...)
+}
+
+// Reset discards the recorded steps.
+func (p *DryRunPlan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = nil
+}
+
+// String formats the steps, one per line.
+func (p *DryRunPlan) String() string {
+	var b strings.Builder
+	for _, s := range p.Steps() {
+		b.WriteString(s.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// WriteTo writes the steps to w, one per line.
+func (p *DryRunPlan) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, p.String())
+	return int64(n), err
+}
+
+func (p *DryRunPlan) record(s DryRunStep) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = append(p.steps, s)
+}
+
+// isDryRun reports whether req should not be sent.
+func (c *Client) isDryRun(req *http.Request, co *callOptions) bool {
+	return (co.dryRun || c.dryRun) && req.Method != http.MethodGet
+}
+
+// skipRequest logs and records a request that isn't sent because of a dry run.
+func (c *Client) skipRequest(req *http.Request) {
+	step := DryRunStep{
+		Operation: requestRoute(req).operation,
+		Method:    req.Method,
+		URL:       req.URL.String(),
+		Time:      time.Now(),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			if b = redact.JSON(b); len(strings.TrimSpace(string(b))) > 0 {
+				step.Body = json.RawMessage(strings.TrimSpace(string(b)))
+			}
+		}
+	}
+
+	attrs := requestAttrs(req)
+	if step.Body != nil {
+		attrs = append(attrs, slog.String("body", string(step.Body)))
+	}
+	c.logger.LogAttrs(req.Context(), slog.LevelInfo, "dry run", attrs...)
+
+	if c.plan != nil {
+		c.plan.record(step)
+	}
+}
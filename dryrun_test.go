@@ -0,0 +1,110 @@
+package cherrygo
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDryRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := &bytes.Buffer{}
+	plan := &DryRunPlan{}
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithDryRun(plan),
+		WithLogger(slog.New(slog.NewJSONHandler(buf, nil))),
+	)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/", func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run request %s %s was sent", r.Method, r.URL.Path)
+	})
+	mux.HandleFunc("GET /v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	srv, resp, err := c.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+	assert.False(t, resp.DryRun)
+	assert.Equal(t, 1, srv.ID)
+
+	resp, err = c.Servers.Delete(t.Context(), 1)
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+
+	srv, resp, err = c.Servers.Reinstall(t.Context(), 1, &ReinstallServerFields{
+		Image:    "ubuntu_24_04_64bit",
+		Hostname: "web",
+		Password: "hunter2Hunter2x",
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Zero(t, srv)
+
+	resp, err = c.Storages.Detach(t.Context(), 2)
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+
+	resp, err = c.IPAddresses.Remove(t.Context(), "abc")
+	require.NoError(t, err)
+	assert.True(t, resp.DryRun)
+
+	steps := plan.Steps()
+	require.Len(t, steps, 4)
+
+	var ops []string
+	for _, s := range steps {
+		ops = append(ops, s.Operation)
+	}
+	assert.Equal(t, []string{"Servers.Delete", "Servers.Reinstall", "Storages.Detach", "IPAddresses.Remove"}, ops)
+
+	assert.Equal(t, http.MethodDelete, steps[0].Method)
+	assert.Equal(t, server.URL+"/v1/servers/1", steps[0].URL)
+	assert.Nil(t, steps[0].Body)
+	assert.Contains(t, string(steps[1].Body), `"type":"reinstall"`)
+
+	printed := plan.String()
+	assert.Equal(t, 4, strings.Count(printed, "\n"))
+	assert.Contains(t, printed, "Storages.Detach: DELETE "+server.URL+"/v1/storages/2/attachments\n")
+	assert.NotContains(t, printed, "hunter2")
+
+	records := logRecords(t, buf)
+	var dryRuns int
+	for _, r := range records {
+		if r["msg"] == "dry run" {
+			dryRuns++
+			assert.Equal(t, "INFO", r["level"])
+		}
+	}
+	assert.Equal(t, 4, dryRuns)
+	assert.NotContains(t, buf.String(), "hunter2")
+
+	plan.Reset()
+	assert.Empty(t, plan.Steps())
+}
+
+func TestDryRunCallOptionRecorded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	plan := &DryRunPlan{}
+	c, err := NewClient(WithURL(server.URL), WithDryRun(plan))
+	require.NoError(t, err)
+
+	_, _, err = c.Storages.Create(t.Context(), 1, &CreateStorage{Size: 10, Region: "eu_nord_1"}, DryRun())
+	require.NoError(t, err)
+
+	require.Len(t, plan.Steps(), 1)
+	assert.Equal(t, "Storages.Create", plan.Steps()[0].Operation)
+	assert.JSONEq(t, `{"description": "", "size": 10, "region": "eu_nord_1"}`, string(plan.Steps()[0].Body))
+}
@@ -0,0 +1,138 @@
+package cherrygo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// Sentinel errors that an [*APIError] matches with [errors.Is],
+// depending on its HTTP status code.
+var (
+	ErrBadRequest          = errors.New("bad request")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrForbidden           = errors.New("forbidden")
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrUnprocessableEntity = errors.New("unprocessable entity")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrServerError         = errors.New("server error")
+)
+
+// Reasons for the client giving up on retrying a request, that its
+// error matches with [errors.Is].
+var (
+	// ErrRetryAttemptLimit means the maximum amount of retries was reached,
+	// see [WithMaxRetries].
+	ErrRetryAttemptLimit = client.ErrAttemptLimit
+
+	// ErrRetryBudget means the retry backoff returned [backoff.Stop].
+	ErrRetryBudget = client.ErrRetryBudget
+
+	// ErrRetryDeadline means waiting for the next attempt would pass the
+	// context deadline, so the client didn't wait. The error also matches
+	// [context.DeadlineExceeded].
+	ErrRetryDeadline = client.ErrRetryDeadline
+)
+
+// APIError is returned for API responses with a non-2xx status code.
+//
+// Use [errors.Is] with the sentinel errors, e.g. [ErrNotFound], to check
+// for a class of failure, or [errors.As] to access the details.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Code is the error code reported in the response body.
+	// Zero if the body could not be parsed.
+	Code int
+
+	// Message is the error message reported in the response body.
+	// Falls back to the HTTP status text if the body could not be parsed.
+	Message string
+
+	// Body is the raw response body, possibly empty.
+	Body []byte
+
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+
+	// Response is the API response. Its body has already been consumed,
+	// see Body instead.
+	Response *Response
+}
+
+func (e *APIError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("error response from API: %v (error code: %v)", e.Message, e.Code)
+	}
+	return fmt.Sprintf("error response from API: %v (status: %d)", e.Message, e.StatusCode)
+}
+
+// Is reports whether target is the sentinel error matching the status code.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnprocessableEntity:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// newAPIError builds an error from a non-2xx response with an already
+// read body. Bodies that aren't JSON, e.g. proxy HTML pages, are kept
+// as is and the message falls back to the status text.
+func newAPIError(req *http.Request, resp *Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Response:   resp,
+	}
+
+	var parsed struct {
+		Code        int      `json:"code"`
+		Message     string   `json:"message"`
+		SingleError string   `json:"error"`
+		Errors      []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		apiErr.Code = parsed.Code
+		switch {
+		case parsed.Message != "":
+			apiErr.Message = parsed.Message
+		case parsed.SingleError != "":
+			apiErr.Message = parsed.SingleError
+		case len(parsed.Errors) > 0:
+			apiErr.Message = strings.Join(parsed.Errors, "; ")
+		}
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+		if apiErr.Message == "" {
+			apiErr.Message = resp.Status
+		}
+	}
+
+	return apiErr
+}
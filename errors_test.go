@@ -0,0 +1,90 @@
+package cherrygo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	cases := []struct {
+		title       string
+		status      int
+		body        string
+		wantCode    int
+		wantMessage string
+		wantIs      error
+	}{
+		{
+			title:       "json body",
+			status:      http.StatusNotFound,
+			body:        `{"code": 404, "message": "Server not found"}`,
+			wantCode:    404,
+			wantMessage: "Server not found",
+			wantIs:      ErrNotFound,
+		},
+		{
+			title:       "errors list",
+			status:      http.StatusUnprocessableEntity,
+			body:        `{"errors": ["hostname is invalid", "region is required"]}`,
+			wantMessage: "hostname is invalid; region is required",
+			wantIs:      ErrUnprocessableEntity,
+		},
+		{
+			title:       "html body",
+			status:      http.StatusBadGateway,
+			body:        `<html><body>Bad Gateway</body></html>`,
+			wantMessage: "Bad Gateway",
+			wantIs:      ErrServerError,
+		},
+		{
+			title:       "empty body",
+			status:      http.StatusTooManyRequests,
+			wantMessage: "Too Many Requests",
+			wantIs:      ErrRateLimited,
+		},
+		{
+			title:       "conflict",
+			status:      http.StatusConflict,
+			body:        `{"code": 409, "message": "Already attached"}`,
+			wantCode:    409,
+			wantMessage: "Already attached",
+			wantIs:      ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			// POST requests without an idempotency key are not retried.
+			mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, err := fmt.Fprint(w, tc.body)
+				require.NoError(t, err)
+			})
+
+			_, resp, err := testClient.Servers.Create(t.Context(), &CreateServer{ProjectID: 1})
+			require.Error(t, err)
+			require.NotNil(t, resp)
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tc.status, apiErr.StatusCode)
+			assert.Equal(t, tc.wantCode, apiErr.Code)
+			assert.Equal(t, tc.wantMessage, apiErr.Message)
+			assert.Equal(t, tc.body, string(apiErr.Body))
+			assert.Equal(t, http.MethodPost, apiErr.Method)
+			assert.Equal(t, "/v1/projects/1/servers", apiErr.Path)
+			assert.Same(t, resp, apiErr.Response)
+
+			assert.ErrorIs(t, err, tc.wantIs)
+			assert.False(t, errors.Is(err, ErrUnauthorized))
+		})
+	}
+}
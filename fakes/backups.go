@@ -0,0 +1,142 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.BackupsService = (*Backups)(nil)
+
+// Backups is a fake [cherrygo.BackupsService].
+type Backups struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListPlansFunc stubs ListPlans.
+	ListPlansFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.BackupStoragePlan, *cherrygo.Response, error)
+
+	// AllPlansFunc stubs AllPlans.
+	AllPlansFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.BackupStoragePlan, error]
+
+	// ListBackupsFunc stubs ListBackups.
+	ListBackupsFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.BackupStorage, *cherrygo.Response, error)
+
+	// AllBackupsFunc stubs AllBackups.
+	AllBackupsFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.BackupStorage, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, backupID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.BackupStorage, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, serverID int, request *cherrygo.CreateBackup, callOpts ...cherrygo.CallOption) (cherrygo.BackupStorage, *cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, id int, request *cherrygo.UpdateBackupStorage, callOpts ...cherrygo.CallOption) (cherrygo.BackupStorage, *cherrygo.Response, error)
+
+	// UpdateBackupMethodFunc stubs UpdateBackupMethod.
+	UpdateBackupMethodFunc func(ctx context.Context, id int, method string, request *cherrygo.UpdateBackupMethod, callOpts ...cherrygo.CallOption) ([]cherrygo.BackupMethod, *cherrygo.Response, error)
+
+	// DeleteFunc stubs Delete.
+	DeleteFunc func(ctx context.Context, backupID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Backups) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Backups."+method, args...)
+}
+
+// ListPlans records the call and returns the result of ListPlansFunc, if set.
+func (f *Backups) ListPlans(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.BackupStoragePlan, *cherrygo.Response, error) {
+	f.record("ListPlans", opts)
+	if f.ListPlansFunc != nil {
+		return f.ListPlansFunc(ctx, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllPlans records the call and, if AllPlansFunc is not set, iterates
+// over pages returned by ListPlans.
+func (f *Backups) AllPlans(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.BackupStoragePlan, error] {
+	f.record("AllPlans", opts)
+	if f.AllPlansFunc != nil {
+		return f.AllPlansFunc(ctx, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.BackupStoragePlan, *cherrygo.Response, error) {
+		return f.ListPlans(ctx, opts, callOpts...)
+	})
+}
+
+// ListBackups records the call and returns the result of ListBackupsFunc, if set.
+func (f *Backups) ListBackups(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.BackupStorage, *cherrygo.Response, error) {
+	f.record("ListBackups", projectID, opts)
+	if f.ListBackupsFunc != nil {
+		return f.ListBackupsFunc(ctx, projectID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllBackups records the call and, if AllBackupsFunc is not set, iterates
+// over pages returned by ListBackups.
+func (f *Backups) AllBackups(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.BackupStorage, error] {
+	f.record("AllBackups", projectID, opts)
+	if f.AllBackupsFunc != nil {
+		return f.AllBackupsFunc(ctx, projectID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.BackupStorage, *cherrygo.Response, error) {
+		return f.ListBackups(ctx, projectID, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Backups) Get(ctx context.Context, backupID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.BackupStorage, *cherrygo.Response, error) {
+	f.record("Get", backupID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, backupID, opts, callOpts...)
+	}
+	return cherrygo.BackupStorage{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *Backups) Create(ctx context.Context, serverID int, request *cherrygo.CreateBackup, callOpts ...cherrygo.CallOption) (cherrygo.BackupStorage, *cherrygo.Response, error) {
+	f.record("Create", serverID, request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, serverID, request, callOpts...)
+	}
+	return cherrygo.BackupStorage{}, &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *Backups) Update(ctx context.Context, id int, request *cherrygo.UpdateBackupStorage, callOpts ...cherrygo.CallOption) (cherrygo.BackupStorage, *cherrygo.Response, error) {
+	f.record("Update", id, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, id, request, callOpts...)
+	}
+	return cherrygo.BackupStorage{}, &cherrygo.Response{}, nil
+}
+
+// UpdateBackupMethod records the call and returns the result of UpdateBackupMethodFunc, if set.
+func (f *Backups) UpdateBackupMethod(ctx context.Context, id int, method string, request *cherrygo.UpdateBackupMethod, callOpts ...cherrygo.CallOption) ([]cherrygo.BackupMethod, *cherrygo.Response, error) {
+	f.record("UpdateBackupMethod", id, method, request)
+	if f.UpdateBackupMethodFunc != nil {
+		return f.UpdateBackupMethodFunc(ctx, id, method, request, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// Delete records the call and returns the result of DeleteFunc, if set.
+func (f *Backups) Delete(ctx context.Context, backupID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Delete", backupID)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, backupID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
@@ -0,0 +1,30 @@
+package fakes_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cherryservers/cherrygo/v4"
+	"github.com/cherryservers/cherrygo/v4/fakes"
+)
+
+func Example() {
+	f := fakes.New()
+	f.Servers.GetFunc = func(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+		return cherrygo.Server{ID: serverID, Status: "deployed"}, &cherrygo.Response{}, nil
+	}
+
+	// Code under test uses the client like a real one.
+	c := f.Client()
+	srv, _, err := c.Servers.Get(context.Background(), 123, nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(srv.Status)
+
+	// In tests, assert on the calls, e.g. with f.AssertCalled(t, "Servers.Get", 123, (*cherrygo.GetOptions)(nil)).
+	fmt.Println(f.Calls())
+	// Output:
+	// deployed
+	// [Servers.Get(123, (*cherrygo.GetOptions)(nil))]
+}
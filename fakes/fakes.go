@@ -0,0 +1,66 @@
+// Package fakes provides configurable fakes of the cherrygo service interfaces,
+// for testing code built on cherrygo without an API server.
+//
+// Every fake records its calls and returns the result of a stub function
+// per method, e.g. GetFunc for Get. Methods without a stub return zero values
+// with an empty response, except All methods, which iterate over the pages
+// returned by their List counterparts. Calls can be checked with the
+// assertions of [Recorder], e.g. [Recorder.AssertCalled], as shown in the example.
+package fakes
+
+import "github.com/cherryservers/cherrygo/v4"
+
+// Fakes holds a fake of every service, recording calls to a shared [Recorder].
+type Fakes struct {
+	*Recorder
+
+	Teams       *Teams
+	Plans       *Plans
+	Images      *Images
+	Projects    *Projects
+	SSHKeys     *SSHKeys
+	Servers     *Servers
+	IPAddresses *IPAddresses
+	Storages    *Storages
+	Regions     *Regions
+	Users       *Users
+	Backups     *Backups
+}
+
+// New creates fakes of every service that share a recorder.
+func New() *Fakes {
+	r := &Recorder{}
+	return &Fakes{
+		Recorder:    r,
+		Teams:       &Teams{Recorder: r},
+		Plans:       &Plans{Recorder: r},
+		Images:      &Images{Recorder: r},
+		Projects:    &Projects{Recorder: r},
+		SSHKeys:     &SSHKeys{Recorder: r},
+		Servers:     &Servers{Recorder: r},
+		IPAddresses: &IPAddresses{Recorder: r},
+		Storages:    &Storages{Recorder: r},
+		Regions:     &Regions{Recorder: r},
+		Users:       &Users{Recorder: r},
+		Backups:     &Backups{Recorder: r},
+	}
+}
+
+// Client returns a client whose services are the fakes.
+//
+// The client is not connected to an API, so only its services may be used.
+func (f *Fakes) Client() *cherrygo.Client {
+	return &cherrygo.Client{
+		Teams:       f.Teams,
+		Plans:       f.Plans,
+		Images:      f.Images,
+		Projects:    f.Projects,
+		SSHKeys:     f.SSHKeys,
+		Servers:     f.Servers,
+		IPAddresses: f.IPAddresses,
+		Storages:    f.Storages,
+		Regions:     f.Regions,
+		Users:       f.Users,
+		Backups:     f.Backups,
+	}
+}
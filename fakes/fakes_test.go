@@ -0,0 +1,114 @@
+package fakes_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cherryservers/cherrygo/v4"
+	"github.com/cherryservers/cherrygo/v4/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deploy is an example of code under test.
+func deploy(ctx context.Context, c *cherrygo.Client, projectID int, hostname string) (cherrygo.Server, error) {
+	srv, _, err := c.Servers.Create(ctx, &cherrygo.CreateServer{ProjectID: projectID, Hostname: hostname})
+	if err != nil {
+		return cherrygo.Server{}, err
+	}
+
+	srv, _, err = c.Servers.WaitForStatus(ctx, srv.ID, cherrygo.StatusDeployed)
+	return srv, err
+}
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestFakes(t *testing.T) {
+	f := fakes.New()
+	f.Servers.CreateFunc = func(_ context.Context, request *cherrygo.CreateServer, _ ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+		return cherrygo.Server{ID: 1, Hostname: request.Hostname}, &cherrygo.Response{}, nil
+	}
+	f.Servers.WaitForStatusFunc = func(_ context.Context, serverID int, _ cherrygo.ServerStatus, _ ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+		return cherrygo.Server{ID: serverID, Status: "deployed"}, &cherrygo.Response{}, nil
+	}
+
+	srv, err := deploy(t.Context(), f.Client(), 10, "web")
+	require.NoError(t, err)
+	assert.Equal(t, "deployed", srv.Status)
+
+	assert.True(t, f.AssertCallOrder(t, "Servers.Create", "Servers.WaitForStatus"))
+	assert.True(t, f.AssertCalled(t, "Servers.Create", &cherrygo.CreateServer{ProjectID: 10, Hostname: "web"}))
+	assert.True(t, f.AssertCalled(t, "Servers.WaitForStatus", 1, cherrygo.StatusDeployed))
+	assert.True(t, f.AssertNumberOfCalls(t, "Servers.Create", 1))
+	assert.True(t, f.AssertNotCalled(t, "Servers.Delete"))
+}
+
+func TestFakesFailedAssertions(t *testing.T) {
+	f := fakes.New()
+
+	_, _, err := f.Client().Regions.Get(t.Context(), "eu_nord_1", nil)
+	require.NoError(t, err)
+	_, err = f.Servers.Delete(t.Context(), 1)
+	require.NoError(t, err)
+
+	rt := &recordingT{}
+	assert.False(t, f.AssertCalled(rt, "Regions.Get", "us_chicago_1", (*cherrygo.GetOptions)(nil)))
+	assert.False(t, f.AssertCalled(rt, "Servers.Get"))
+	assert.False(t, f.AssertNotCalled(rt, "Servers.Delete"))
+	assert.False(t, f.AssertCallOrder(rt, "Servers.Delete", "Regions.Get"))
+	assert.False(t, f.AssertNumberOfCalls(rt, "Regions.Get", 2))
+	require.Len(t, rt.errors, 5)
+	assert.Contains(t, rt.errors[0], `Regions.Get("eu_nord_1", (*cherrygo.GetOptions)(nil))`)
+
+	f.Reset()
+	assert.Empty(t, f.Calls())
+}
+
+func TestFakeDefaults(t *testing.T) {
+	servers := &fakes.Servers{}
+
+	srv, resp, err := servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Zero(t, srv)
+
+	assert.Equal(t, []fakes.Call{{Method: "Servers.Get", Args: []any{1, (*cherrygo.GetOptions)(nil)}}}, servers.Recorder.Calls())
+}
+
+func TestFakeAllPaginatesList(t *testing.T) {
+	keys := []cherrygo.SSHKey{{ID: 1}, {ID: 2}, {ID: 3}}
+	errList := errors.New("list failed")
+
+	f := fakes.New()
+	f.Projects.ListSSHKeysFunc = func(_ context.Context, _ int, opts *cherrygo.GetOptions, _ ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+		if opts.Offset >= len(keys) {
+			return nil, nil, errList
+		}
+		end := min(opts.Offset+opts.Limit, len(keys))
+		return keys[opts.Offset:end], &cherrygo.Response{}, nil
+	}
+
+	var got []cherrygo.SSHKey
+	var gotErr error
+	for key, err := range f.Projects.AllSSHKeys(t.Context(), 5, &cherrygo.GetOptions{Limit: 2}) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, key)
+	}
+
+	assert.Equal(t, keys, got)
+	assert.NoError(t, gotErr)
+	f.AssertCallOrder(t, "Projects.AllSSHKeys", "Projects.ListSSHKeys", "Projects.ListSSHKeys")
+}
@@ -0,0 +1,55 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.ImagesService = (*Images)(nil)
+
+// Images is a fake [cherrygo.ImagesService].
+type Images struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, plan string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Image, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, plan string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Image, error]
+
+	once sync.Once
+}
+
+func (f *Images) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Images."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Images) List(ctx context.Context, plan string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Image, *cherrygo.Response, error) {
+	f.record("List", plan, opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, plan, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Images) All(ctx context.Context, plan string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Image, error] {
+	f.record("All", plan, opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, plan, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.Image, *cherrygo.Response, error) {
+		return f.List(ctx, plan, opts, callOpts...)
+	})
+}
@@ -0,0 +1,127 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.IPAddressesService = (*IPAddresses)(nil)
+
+// IPAddresses is a fake [cherrygo.IPAddressesService].
+type IPAddresses struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.IPAddress, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.IPAddress, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, ipID string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, projectID int, request *cherrygo.CreateIPAddress, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error)
+
+	// RemoveFunc stubs Remove.
+	RemoveFunc func(ctx context.Context, ipID string, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, ipID string, request *cherrygo.UpdateIPAddress, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error)
+
+	// AssignFunc stubs Assign.
+	AssignFunc func(ctx context.Context, ipID string, request *cherrygo.AssignIPAddress, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error)
+
+	// UnassignFunc stubs Unassign.
+	UnassignFunc func(ctx context.Context, ipID string, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *IPAddresses) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("IPAddresses."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *IPAddresses) List(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.IPAddress, *cherrygo.Response, error) {
+	f.record("List", projectID, opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, projectID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *IPAddresses) All(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.IPAddress, error] {
+	f.record("All", projectID, opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, projectID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.IPAddress, *cherrygo.Response, error) {
+		return f.List(ctx, projectID, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *IPAddresses) Get(ctx context.Context, ipID string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	f.record("Get", ipID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ipID, opts, callOpts...)
+	}
+	return cherrygo.IPAddress{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *IPAddresses) Create(ctx context.Context, projectID int, request *cherrygo.CreateIPAddress, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	f.record("Create", projectID, request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, projectID, request, callOpts...)
+	}
+	return cherrygo.IPAddress{}, &cherrygo.Response{}, nil
+}
+
+// Remove records the call and returns the result of RemoveFunc, if set.
+func (f *IPAddresses) Remove(ctx context.Context, ipID string, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Remove", ipID)
+	if f.RemoveFunc != nil {
+		return f.RemoveFunc(ctx, ipID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *IPAddresses) Update(ctx context.Context, ipID string, request *cherrygo.UpdateIPAddress, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	f.record("Update", ipID, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ipID, request, callOpts...)
+	}
+	return cherrygo.IPAddress{}, &cherrygo.Response{}, nil
+}
+
+// Assign records the call and returns the result of AssignFunc, if set.
+func (f *IPAddresses) Assign(ctx context.Context, ipID string, request *cherrygo.AssignIPAddress, callOpts ...cherrygo.CallOption) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	f.record("Assign", ipID, request)
+	if f.AssignFunc != nil {
+		return f.AssignFunc(ctx, ipID, request, callOpts...)
+	}
+	return cherrygo.IPAddress{}, &cherrygo.Response{}, nil
+}
+
+// Unassign records the call and returns the result of UnassignFunc, if set.
+func (f *IPAddresses) Unassign(ctx context.Context, ipID string, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Unassign", ipID)
+	if f.UnassignFunc != nil {
+		return f.UnassignFunc(ctx, ipID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
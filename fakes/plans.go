@@ -0,0 +1,133 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.PlansService = (*Plans)(nil)
+
+// Plans is a fake [cherrygo.PlansService].
+type Plans struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Plan, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Plan, error]
+
+	// GetBySlugFunc stubs GetBySlug.
+	GetBySlugFunc func(ctx context.Context, slug string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Plan, *cherrygo.Response, error)
+
+	// GetByIDFunc stubs GetByID.
+	GetByIDFunc func(ctx context.Context, id int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Plan, *cherrygo.Response, error)
+
+	// ListPrebuiltPlansFunc stubs ListPrebuiltPlans.
+	ListPrebuiltPlansFunc func(ctx context.Context, basePlan string, region string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.PrebuiltPlan, *cherrygo.Response, error)
+
+	// AllPrebuiltPlansFunc stubs AllPrebuiltPlans.
+	AllPrebuiltPlansFunc func(ctx context.Context, basePlan string, region string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.PrebuiltPlan, error]
+
+	// ListPrebuiltTeamPlansFunc stubs ListPrebuiltTeamPlans.
+	ListPrebuiltTeamPlansFunc func(ctx context.Context, basePlan string, region string, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.PrebuiltPlan, *cherrygo.Response, error)
+
+	// AllPrebuiltTeamPlansFunc stubs AllPrebuiltTeamPlans.
+	AllPrebuiltTeamPlansFunc func(ctx context.Context, basePlan string, region string, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.PrebuiltPlan, error]
+
+	once sync.Once
+}
+
+func (f *Plans) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Plans."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Plans) List(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Plan, *cherrygo.Response, error) {
+	f.record("List", teamID, opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, teamID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Plans) All(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Plan, error] {
+	f.record("All", teamID, opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, teamID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.Plan, *cherrygo.Response, error) {
+		return f.List(ctx, teamID, opts, callOpts...)
+	})
+}
+
+// GetBySlug records the call and returns the result of GetBySlugFunc, if set.
+func (f *Plans) GetBySlug(ctx context.Context, slug string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Plan, *cherrygo.Response, error) {
+	f.record("GetBySlug", slug, opts)
+	if f.GetBySlugFunc != nil {
+		return f.GetBySlugFunc(ctx, slug, opts, callOpts...)
+	}
+	return cherrygo.Plan{}, &cherrygo.Response{}, nil
+}
+
+// GetByID records the call and returns the result of GetByIDFunc, if set.
+func (f *Plans) GetByID(ctx context.Context, id int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Plan, *cherrygo.Response, error) {
+	f.record("GetByID", id, opts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, opts, callOpts...)
+	}
+	return cherrygo.Plan{}, &cherrygo.Response{}, nil
+}
+
+// ListPrebuiltPlans records the call and returns the result of ListPrebuiltPlansFunc, if set.
+func (f *Plans) ListPrebuiltPlans(ctx context.Context, basePlan string, region string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.PrebuiltPlan, *cherrygo.Response, error) {
+	f.record("ListPrebuiltPlans", basePlan, region, opts)
+	if f.ListPrebuiltPlansFunc != nil {
+		return f.ListPrebuiltPlansFunc(ctx, basePlan, region, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllPrebuiltPlans records the call and, if AllPrebuiltPlansFunc is not set, iterates
+// over pages returned by ListPrebuiltPlans.
+func (f *Plans) AllPrebuiltPlans(ctx context.Context, basePlan string, region string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.PrebuiltPlan, error] {
+	f.record("AllPrebuiltPlans", basePlan, region, opts)
+	if f.AllPrebuiltPlansFunc != nil {
+		return f.AllPrebuiltPlansFunc(ctx, basePlan, region, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.PrebuiltPlan, *cherrygo.Response, error) {
+		return f.ListPrebuiltPlans(ctx, basePlan, region, opts, callOpts...)
+	})
+}
+
+// ListPrebuiltTeamPlans records the call and returns the result of ListPrebuiltTeamPlansFunc, if set.
+func (f *Plans) ListPrebuiltTeamPlans(ctx context.Context, basePlan string, region string, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.PrebuiltPlan, *cherrygo.Response, error) {
+	f.record("ListPrebuiltTeamPlans", basePlan, region, teamID, opts)
+	if f.ListPrebuiltTeamPlansFunc != nil {
+		return f.ListPrebuiltTeamPlansFunc(ctx, basePlan, region, teamID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllPrebuiltTeamPlans records the call and, if AllPrebuiltTeamPlansFunc is not set, iterates
+// over pages returned by ListPrebuiltTeamPlans.
+func (f *Plans) AllPrebuiltTeamPlans(ctx context.Context, basePlan string, region string, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.PrebuiltPlan, error] {
+	f.record("AllPrebuiltTeamPlans", basePlan, region, teamID, opts)
+	if f.AllPrebuiltTeamPlansFunc != nil {
+		return f.AllPrebuiltTeamPlansFunc(ctx, basePlan, region, teamID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.PrebuiltPlan, *cherrygo.Response, error) {
+		return f.ListPrebuiltTeamPlans(ctx, basePlan, region, teamID, opts, callOpts...)
+	})
+}
@@ -0,0 +1,130 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.ProjectsService = (*Projects)(nil)
+
+// Projects is a fake [cherrygo.ProjectsService].
+type Projects struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Project, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Project, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Project, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, teamID int, request *cherrygo.CreateProject, callOpts ...cherrygo.CallOption) (cherrygo.Project, *cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, projectID int, request *cherrygo.UpdateProject, callOpts ...cherrygo.CallOption) (cherrygo.Project, *cherrygo.Response, error)
+
+	// ListSSHKeysFunc stubs ListSSHKeys.
+	ListSSHKeysFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error)
+
+	// AllSSHKeysFunc stubs AllSSHKeys.
+	AllSSHKeysFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.SSHKey, error]
+
+	// DeleteFunc stubs Delete.
+	DeleteFunc func(ctx context.Context, projectID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Projects) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Projects."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Projects) List(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Project, *cherrygo.Response, error) {
+	f.record("List", teamID, opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, teamID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Projects) All(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Project, error] {
+	f.record("All", teamID, opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, teamID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+		return f.List(ctx, teamID, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Projects) Get(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Project, *cherrygo.Response, error) {
+	f.record("Get", projectID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, projectID, opts, callOpts...)
+	}
+	return cherrygo.Project{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *Projects) Create(ctx context.Context, teamID int, request *cherrygo.CreateProject, callOpts ...cherrygo.CallOption) (cherrygo.Project, *cherrygo.Response, error) {
+	f.record("Create", teamID, request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, teamID, request, callOpts...)
+	}
+	return cherrygo.Project{}, &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *Projects) Update(ctx context.Context, projectID int, request *cherrygo.UpdateProject, callOpts ...cherrygo.CallOption) (cherrygo.Project, *cherrygo.Response, error) {
+	f.record("Update", projectID, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, projectID, request, callOpts...)
+	}
+	return cherrygo.Project{}, &cherrygo.Response{}, nil
+}
+
+// ListSSHKeys records the call and returns the result of ListSSHKeysFunc, if set.
+func (f *Projects) ListSSHKeys(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+	f.record("ListSSHKeys", projectID, opts)
+	if f.ListSSHKeysFunc != nil {
+		return f.ListSSHKeysFunc(ctx, projectID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllSSHKeys records the call and, if AllSSHKeysFunc is not set, iterates
+// over pages returned by ListSSHKeys.
+func (f *Projects) AllSSHKeys(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.SSHKey, error] {
+	f.record("AllSSHKeys", projectID, opts)
+	if f.AllSSHKeysFunc != nil {
+		return f.AllSSHKeysFunc(ctx, projectID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+		return f.ListSSHKeys(ctx, projectID, opts, callOpts...)
+	})
+}
+
+// Delete records the call and returns the result of DeleteFunc, if set.
+func (f *Projects) Delete(ctx context.Context, projectID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Delete", projectID)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, projectID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
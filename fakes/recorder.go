@@ -0,0 +1,143 @@
+package fakes
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Call is a recorded method call.
+type Call struct {
+	// Method is the service and method name, e.g. Servers.Get.
+	Method string
+
+	// Args are the call arguments, without the context.
+	Args []any
+}
+
+// String formats the call with its arguments, e.g. Servers.Get(123, (*cherrygo.GetOptions)(nil)).
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = fmt.Sprintf("%#v", arg)
+	}
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// TestingT is the subset of [testing.TB] used by assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Recorder records calls made to fakes. Fakes that share a recorder
+// record calls in the order they were made across services.
+// Safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to method, e.g. Servers.Get, in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	var calls []Call
+	for _, c := range r.Calls() {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets all recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled asserts that method was called with args at least once.
+// Arguments are compared with [reflect.DeepEqual]. If no args are given,
+// any call to method satisfies the assertion.
+func (r *Recorder) AssertCalled(t TestingT, method string, args ...any) bool {
+	t.Helper()
+
+	calls := r.CallsTo(method)
+	for _, c := range calls {
+		if len(args) == 0 || reflect.DeepEqual(c.Args, args) {
+			return true
+		}
+	}
+
+	if len(calls) == 0 {
+		t.Errorf("expected a call to %s, got none", method)
+	} else {
+		t.Errorf("expected a call to %s, got:\n%s", Call{Method: method, Args: args}, formatCalls(calls))
+	}
+	return false
+}
+
+// AssertNotCalled asserts that method was not called.
+func (r *Recorder) AssertNotCalled(t TestingT, method string) bool {
+	t.Helper()
+
+	if calls := r.CallsTo(method); len(calls) > 0 {
+		t.Errorf("expected no calls to %s, got:\n%s", method, formatCalls(calls))
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls asserts that method was called n times.
+func (r *Recorder) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+
+	if got := len(r.CallsTo(method)); got != n {
+		t.Errorf("expected %d calls to %s, got %d", n, method, got)
+		return false
+	}
+	return true
+}
+
+// AssertCallOrder asserts that methods were called in the given order.
+// Other calls may happen before, between or after them.
+func (r *Recorder) AssertCallOrder(t TestingT, methods ...string) bool {
+	t.Helper()
+
+	calls := r.Calls()
+	next := 0
+	for _, c := range calls {
+		if next < len(methods) && c.Method == methods[next] {
+			next++
+		}
+	}
+
+	if next < len(methods) {
+		t.Errorf("expected calls in order %s, missing %s after %d matched calls, got:\n%s",
+			strings.Join(methods, ", "), methods[next], next, formatCalls(calls))
+		return false
+	}
+	return true
+}
+
+func formatCalls(calls []Call) string {
+	var b strings.Builder
+	for _, c := range calls {
+		b.WriteString("\t" + c.String() + "\n")
+	}
+	return b.String()
+}
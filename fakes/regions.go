@@ -0,0 +1,67 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.RegionsService = (*Regions)(nil)
+
+// Regions is a fake [cherrygo.RegionsService].
+type Regions struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Region, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Region, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, region string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Region, *cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Regions) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Regions."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Regions) List(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Region, *cherrygo.Response, error) {
+	f.record("List", opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Regions) All(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Region, error] {
+	f.record("All", opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.Region, *cherrygo.Response, error) {
+		return f.List(ctx, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Regions) Get(ctx context.Context, region string, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Region, *cherrygo.Response, error) {
+	f.record("Get", region, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, region, opts, callOpts...)
+	}
+	return cherrygo.Region{}, &cherrygo.Response{}, nil
+}
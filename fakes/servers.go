@@ -0,0 +1,289 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.ServersService = (*Servers)(nil)
+
+// Servers is a fake [cherrygo.ServersService].
+type Servers struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Server, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Server, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// PowerOffFunc stubs PowerOff.
+	PowerOffFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// PowerOnFunc stubs PowerOn.
+	PowerOnFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, request *cherrygo.CreateServer, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// DeleteFunc stubs Delete.
+	DeleteFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	// PowerStateFunc stubs PowerState.
+	PowerStateFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.PowerState, *cherrygo.Response, error)
+
+	// RebootFunc stubs Reboot.
+	RebootFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// EnterRescueModeFunc stubs EnterRescueMode.
+	EnterRescueModeFunc func(ctx context.Context, serverID int, fields *cherrygo.RescueServerFields, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// ExitRescueModeFunc stubs ExitRescueMode.
+	ExitRescueModeFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, serverID int, request *cherrygo.UpdateServer, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// ReinstallFunc stubs Reinstall.
+	ReinstallFunc func(ctx context.Context, serverID int, fields *cherrygo.ReinstallServerFields, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// ListSSHKeysFunc stubs ListSSHKeys.
+	ListSSHKeysFunc func(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error)
+
+	// AllSSHKeysFunc stubs AllSSHKeys.
+	AllSSHKeysFunc func(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.SSHKey, error]
+
+	// ResetBMCPasswordFunc stubs ResetBMCPassword.
+	ResetBMCPasswordFunc func(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// ListCyclesFunc stubs ListCycles.
+	ListCyclesFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.ServerCycle, *cherrygo.Response, error)
+
+	// AllCyclesFunc stubs AllCycles.
+	AllCyclesFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.ServerCycle, error]
+
+	// UpgradeFunc stubs Upgrade.
+	UpgradeFunc func(ctx context.Context, serverID int, plan string, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// AllowBMCAccessFunc stubs AllowBMCAccess.
+	AllowBMCAccessFunc func(ctx context.Context, serverID int, ip4 string, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	// WaitForStatusFunc stubs WaitForStatus.
+	WaitForStatusFunc func(ctx context.Context, serverID int, status cherrygo.ServerStatus, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Servers) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Servers."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Servers) List(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Server, *cherrygo.Response, error) {
+	f.record("List", projectID, opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, projectID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Servers) All(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Server, error] {
+	f.record("All", projectID, opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, projectID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.Server, *cherrygo.Response, error) {
+		return f.List(ctx, projectID, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Servers) Get(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("Get", serverID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, serverID, opts, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// PowerOff records the call and returns the result of PowerOffFunc, if set.
+func (f *Servers) PowerOff(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("PowerOff", serverID)
+	if f.PowerOffFunc != nil {
+		return f.PowerOffFunc(ctx, serverID, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// PowerOn records the call and returns the result of PowerOnFunc, if set.
+func (f *Servers) PowerOn(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("PowerOn", serverID)
+	if f.PowerOnFunc != nil {
+		return f.PowerOnFunc(ctx, serverID, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *Servers) Create(ctx context.Context, request *cherrygo.CreateServer, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("Create", request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, request, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// Delete records the call and returns the result of DeleteFunc, if set.
+func (f *Servers) Delete(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Delete", serverID)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, serverID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
+
+// PowerState records the call and returns the result of PowerStateFunc, if set.
+func (f *Servers) PowerState(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.PowerState, *cherrygo.Response, error) {
+	f.record("PowerState", serverID)
+	if f.PowerStateFunc != nil {
+		return f.PowerStateFunc(ctx, serverID, callOpts...)
+	}
+	return cherrygo.PowerState{}, &cherrygo.Response{}, nil
+}
+
+// Reboot records the call and returns the result of RebootFunc, if set.
+func (f *Servers) Reboot(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("Reboot", serverID)
+	if f.RebootFunc != nil {
+		return f.RebootFunc(ctx, serverID, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// EnterRescueMode records the call and returns the result of EnterRescueModeFunc, if set.
+func (f *Servers) EnterRescueMode(ctx context.Context, serverID int, fields *cherrygo.RescueServerFields, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("EnterRescueMode", serverID, fields)
+	if f.EnterRescueModeFunc != nil {
+		return f.EnterRescueModeFunc(ctx, serverID, fields, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// ExitRescueMode records the call and returns the result of ExitRescueModeFunc, if set.
+func (f *Servers) ExitRescueMode(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("ExitRescueMode", serverID)
+	if f.ExitRescueModeFunc != nil {
+		return f.ExitRescueModeFunc(ctx, serverID, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *Servers) Update(ctx context.Context, serverID int, request *cherrygo.UpdateServer, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("Update", serverID, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, serverID, request, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// Reinstall records the call and returns the result of ReinstallFunc, if set.
+func (f *Servers) Reinstall(ctx context.Context, serverID int, fields *cherrygo.ReinstallServerFields, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("Reinstall", serverID, fields)
+	if f.ReinstallFunc != nil {
+		return f.ReinstallFunc(ctx, serverID, fields, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// ListSSHKeys records the call and returns the result of ListSSHKeysFunc, if set.
+func (f *Servers) ListSSHKeys(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+	f.record("ListSSHKeys", serverID, opts)
+	if f.ListSSHKeysFunc != nil {
+		return f.ListSSHKeysFunc(ctx, serverID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllSSHKeys records the call and, if AllSSHKeysFunc is not set, iterates
+// over pages returned by ListSSHKeys.
+func (f *Servers) AllSSHKeys(ctx context.Context, serverID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.SSHKey, error] {
+	f.record("AllSSHKeys", serverID, opts)
+	if f.AllSSHKeysFunc != nil {
+		return f.AllSSHKeysFunc(ctx, serverID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+		return f.ListSSHKeys(ctx, serverID, opts, callOpts...)
+	})
+}
+
+// ResetBMCPassword records the call and returns the result of ResetBMCPasswordFunc, if set.
+func (f *Servers) ResetBMCPassword(ctx context.Context, serverID int, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("ResetBMCPassword", serverID)
+	if f.ResetBMCPasswordFunc != nil {
+		return f.ResetBMCPasswordFunc(ctx, serverID, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// ListCycles records the call and returns the result of ListCyclesFunc, if set.
+func (f *Servers) ListCycles(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.ServerCycle, *cherrygo.Response, error) {
+	f.record("ListCycles", opts)
+	if f.ListCyclesFunc != nil {
+		return f.ListCyclesFunc(ctx, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// AllCycles records the call and, if AllCyclesFunc is not set, iterates
+// over pages returned by ListCycles.
+func (f *Servers) AllCycles(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.ServerCycle, error] {
+	f.record("AllCycles", opts)
+	if f.AllCyclesFunc != nil {
+		return f.AllCyclesFunc(ctx, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.ServerCycle, *cherrygo.Response, error) {
+		return f.ListCycles(ctx, opts, callOpts...)
+	})
+}
+
+// Upgrade records the call and returns the result of UpgradeFunc, if set.
+func (f *Servers) Upgrade(ctx context.Context, serverID int, plan string, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("Upgrade", serverID, plan)
+	if f.UpgradeFunc != nil {
+		return f.UpgradeFunc(ctx, serverID, plan, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// AllowBMCAccess records the call and returns the result of AllowBMCAccessFunc, if set.
+func (f *Servers) AllowBMCAccess(ctx context.Context, serverID int, ip4 string, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("AllowBMCAccess", serverID, ip4)
+	if f.AllowBMCAccessFunc != nil {
+		return f.AllowBMCAccessFunc(ctx, serverID, ip4, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
+
+// WaitForStatus records the call and returns the result of WaitForStatusFunc, if set.
+func (f *Servers) WaitForStatus(ctx context.Context, serverID int, status cherrygo.ServerStatus, callOpts ...cherrygo.CallOption) (cherrygo.Server, *cherrygo.Response, error) {
+	f.record("WaitForStatus", serverID, status)
+	if f.WaitForStatusFunc != nil {
+		return f.WaitForStatusFunc(ctx, serverID, status, callOpts...)
+	}
+	return cherrygo.Server{}, &cherrygo.Response{}, nil
+}
@@ -0,0 +1,103 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.SSHKeysService = (*SSHKeys)(nil)
+
+// SSHKeys is a fake [cherrygo.SSHKeysService].
+type SSHKeys struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.SSHKey, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, sshKeyID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.SSHKey, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, request *cherrygo.CreateSSHKey, callOpts ...cherrygo.CallOption) (cherrygo.SSHKey, *cherrygo.Response, error)
+
+	// DeleteFunc stubs Delete.
+	DeleteFunc func(ctx context.Context, sshKeyID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, sshKeyID int, request *cherrygo.UpdateSSHKey, callOpts ...cherrygo.CallOption) (cherrygo.SSHKey, *cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *SSHKeys) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("SSHKeys."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *SSHKeys) List(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+	f.record("List", opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *SSHKeys) All(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.SSHKey, error] {
+	f.record("All", opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+		return f.List(ctx, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *SSHKeys) Get(ctx context.Context, sshKeyID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.SSHKey, *cherrygo.Response, error) {
+	f.record("Get", sshKeyID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, sshKeyID, opts, callOpts...)
+	}
+	return cherrygo.SSHKey{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *SSHKeys) Create(ctx context.Context, request *cherrygo.CreateSSHKey, callOpts ...cherrygo.CallOption) (cherrygo.SSHKey, *cherrygo.Response, error) {
+	f.record("Create", request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, request, callOpts...)
+	}
+	return cherrygo.SSHKey{}, &cherrygo.Response{}, nil
+}
+
+// Delete records the call and returns the result of DeleteFunc, if set.
+func (f *SSHKeys) Delete(ctx context.Context, sshKeyID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Delete", sshKeyID)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, sshKeyID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *SSHKeys) Update(ctx context.Context, sshKeyID int, request *cherrygo.UpdateSSHKey, callOpts ...cherrygo.CallOption) (cherrygo.SSHKey, *cherrygo.Response, error) {
+	f.record("Update", sshKeyID, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, sshKeyID, request, callOpts...)
+	}
+	return cherrygo.SSHKey{}, &cherrygo.Response{}, nil
+}
@@ -0,0 +1,127 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.StoragesService = (*Storages)(nil)
+
+// Storages is a fake [cherrygo.StoragesService].
+type Storages struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.BlockStorage, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.BlockStorage, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, storageID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, projectID int, request *cherrygo.CreateStorage, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error)
+
+	// DeleteFunc stubs Delete.
+	DeleteFunc func(ctx context.Context, storageID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	// AttachFunc stubs Attach.
+	AttachFunc func(ctx context.Context, storageID int, request *cherrygo.AttachTo, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error)
+
+	// DetachFunc stubs Detach.
+	DetachFunc func(ctx context.Context, storageID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, storageID int, request *cherrygo.UpdateStorage, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Storages) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Storages."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Storages) List(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.BlockStorage, *cherrygo.Response, error) {
+	f.record("List", projectID, opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, projectID, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Storages) All(ctx context.Context, projectID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.BlockStorage, error] {
+	f.record("All", projectID, opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, projectID, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.BlockStorage, *cherrygo.Response, error) {
+		return f.List(ctx, projectID, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Storages) Get(ctx context.Context, storageID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	f.record("Get", storageID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, storageID, opts, callOpts...)
+	}
+	return cherrygo.BlockStorage{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *Storages) Create(ctx context.Context, projectID int, request *cherrygo.CreateStorage, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	f.record("Create", projectID, request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, projectID, request, callOpts...)
+	}
+	return cherrygo.BlockStorage{}, &cherrygo.Response{}, nil
+}
+
+// Delete records the call and returns the result of DeleteFunc, if set.
+func (f *Storages) Delete(ctx context.Context, storageID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Delete", storageID)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, storageID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
+
+// Attach records the call and returns the result of AttachFunc, if set.
+func (f *Storages) Attach(ctx context.Context, storageID int, request *cherrygo.AttachTo, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	f.record("Attach", storageID, request)
+	if f.AttachFunc != nil {
+		return f.AttachFunc(ctx, storageID, request, callOpts...)
+	}
+	return cherrygo.BlockStorage{}, &cherrygo.Response{}, nil
+}
+
+// Detach records the call and returns the result of DetachFunc, if set.
+func (f *Storages) Detach(ctx context.Context, storageID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Detach", storageID)
+	if f.DetachFunc != nil {
+		return f.DetachFunc(ctx, storageID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *Storages) Update(ctx context.Context, storageID int, request *cherrygo.UpdateStorage, callOpts ...cherrygo.CallOption) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	f.record("Update", storageID, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, storageID, request, callOpts...)
+	}
+	return cherrygo.BlockStorage{}, &cherrygo.Response{}, nil
+}
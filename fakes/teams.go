@@ -0,0 +1,103 @@
+package fakes
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.TeamsService = (*Teams)(nil)
+
+// Teams is a fake [cherrygo.TeamsService].
+type Teams struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// ListFunc stubs List.
+	ListFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Team, *cherrygo.Response, error)
+
+	// AllFunc stubs All.
+	AllFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Team, error]
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Team, *cherrygo.Response, error)
+
+	// CreateFunc stubs Create.
+	CreateFunc func(ctx context.Context, request *cherrygo.CreateTeam, callOpts ...cherrygo.CallOption) (cherrygo.Team, *cherrygo.Response, error)
+
+	// UpdateFunc stubs Update.
+	UpdateFunc func(ctx context.Context, teamID int, request *cherrygo.UpdateTeam, callOpts ...cherrygo.CallOption) (cherrygo.Team, *cherrygo.Response, error)
+
+	// DeleteFunc stubs Delete.
+	DeleteFunc func(ctx context.Context, teamID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Teams) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Teams."+method, args...)
+}
+
+// List records the call and returns the result of ListFunc, if set.
+func (f *Teams) List(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) ([]cherrygo.Team, *cherrygo.Response, error) {
+	f.record("List", opts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, opts, callOpts...)
+	}
+	return nil, &cherrygo.Response{}, nil
+}
+
+// All records the call and, if AllFunc is not set, iterates
+// over pages returned by List.
+func (f *Teams) All(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) iter.Seq2[cherrygo.Team, error] {
+	f.record("All", opts)
+	if f.AllFunc != nil {
+		return f.AllFunc(ctx, opts, callOpts...)
+	}
+	return cherrygo.Paginate(ctx, opts, func(ctx context.Context, opts *cherrygo.GetOptions) ([]cherrygo.Team, *cherrygo.Response, error) {
+		return f.List(ctx, opts, callOpts...)
+	})
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Teams) Get(ctx context.Context, teamID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.Team, *cherrygo.Response, error) {
+	f.record("Get", teamID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, teamID, opts, callOpts...)
+	}
+	return cherrygo.Team{}, &cherrygo.Response{}, nil
+}
+
+// Create records the call and returns the result of CreateFunc, if set.
+func (f *Teams) Create(ctx context.Context, request *cherrygo.CreateTeam, callOpts ...cherrygo.CallOption) (cherrygo.Team, *cherrygo.Response, error) {
+	f.record("Create", request)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, request, callOpts...)
+	}
+	return cherrygo.Team{}, &cherrygo.Response{}, nil
+}
+
+// Update records the call and returns the result of UpdateFunc, if set.
+func (f *Teams) Update(ctx context.Context, teamID int, request *cherrygo.UpdateTeam, callOpts ...cherrygo.CallOption) (cherrygo.Team, *cherrygo.Response, error) {
+	f.record("Update", teamID, request)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, teamID, request, callOpts...)
+	}
+	return cherrygo.Team{}, &cherrygo.Response{}, nil
+}
+
+// Delete records the call and returns the result of DeleteFunc, if set.
+func (f *Teams) Delete(ctx context.Context, teamID int, callOpts ...cherrygo.CallOption) (*cherrygo.Response, error) {
+	f.record("Delete", teamID)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, teamID, callOpts...)
+	}
+	return &cherrygo.Response{}, nil
+}
@@ -0,0 +1,51 @@
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v4"
+)
+
+var _ cherrygo.UsersService = (*Users)(nil)
+
+// Users is a fake [cherrygo.UsersService].
+type Users struct {
+	// Recorder records the calls. A new recorder is used if nil.
+	Recorder *Recorder
+
+	// CurrentUserFunc stubs CurrentUser.
+	CurrentUserFunc func(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.User, *cherrygo.Response, error)
+
+	// GetFunc stubs Get.
+	GetFunc func(ctx context.Context, userID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.User, *cherrygo.Response, error)
+
+	once sync.Once
+}
+
+func (f *Users) record(method string, args ...any) {
+	f.once.Do(func() {
+		if f.Recorder == nil {
+			f.Recorder = &Recorder{}
+		}
+	})
+	f.Recorder.record("Users."+method, args...)
+}
+
+// CurrentUser records the call and returns the result of CurrentUserFunc, if set.
+func (f *Users) CurrentUser(ctx context.Context, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.User, *cherrygo.Response, error) {
+	f.record("CurrentUser", opts)
+	if f.CurrentUserFunc != nil {
+		return f.CurrentUserFunc(ctx, opts, callOpts...)
+	}
+	return cherrygo.User{}, &cherrygo.Response{}, nil
+}
+
+// Get records the call and returns the result of GetFunc, if set.
+func (f *Users) Get(ctx context.Context, userID int, opts *cherrygo.GetOptions, callOpts ...cherrygo.CallOption) (cherrygo.User, *cherrygo.Response, error) {
+	f.record("Get", userID, opts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, userID, opts, callOpts...)
+	}
+	return cherrygo.User{}, &cherrygo.Response{}, nil
+}
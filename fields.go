@@ -0,0 +1,187 @@
+package cherrygo
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldSelector is a set of fields of the response type T that the API
+// should return, checked against the JSON names of the fields of T.
+// Nested fields are selected with dotted paths, e.g. "ip_addresses.address"
+// of [Server], and selecting a field selects all of its nested fields.
+//
+// Fields that aren't selected are left zero in the response, see [FieldSelector.Zeroed].
+type FieldSelector[T any] struct {
+	fields []string
+}
+
+// SelectFields returns a selector of fields of T,
+// or an error if any of them isn't a field of T.
+func SelectFields[T any](fields ...string) (FieldSelector[T], error) {
+	t := reflect.TypeFor[T]()
+	if structType(t) == nil {
+		return FieldSelector[T]{}, fmt.Errorf("can't select fields of %s, it isn't a struct", t)
+	}
+
+	for _, f := range fields {
+		if _, err := fieldType(t, f); err != nil {
+			return FieldSelector[T]{}, err
+		}
+	}
+	return FieldSelector[T]{fields: slices.Clone(fields)}, nil
+}
+
+// MustSelectFields is like [SelectFields] but panics on unknown fields.
+// It's meant for selectors declared as package variables.
+func MustSelectFields[T any](fields ...string) FieldSelector[T] {
+	s, err := SelectFields[T](fields...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Fields returns the selected fields, e.g. to set [GetOptions.Fields].
+func (s FieldSelector[T]) Fields() []string {
+	return slices.Clone(s.fields)
+}
+
+// Option returns a [Fields] call option that selects the fields.
+func (s FieldSelector[T]) Option() CallOption {
+	return Fields(s.Fields()...)
+}
+
+// Zeroed returns the paths of the fields of T that are left zero in
+// responses, i.e. the outermost fields that aren't selected. Fields
+// with nested selected fields aren't zeroed, but their other nested fields are.
+// An empty selector selects every field, so none are zeroed.
+func (s FieldSelector[T]) Zeroed() []string {
+	if len(s.fields) == 0 {
+		return nil
+	}
+
+	selected := make(map[string]bool, len(s.fields))
+	for _, f := range s.fields {
+		selected[f] = true
+	}
+
+	var zeroed []string
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for _, name := range jsonFields(t) {
+			path := prefix + name
+			if selected[path] {
+				continue
+			}
+			if !hasSelectedChild(s.fields, path) {
+				zeroed = append(zeroed, path)
+				continue
+			}
+			ft, _ := fieldType(t, name)
+			walk(structType(ft), path+".")
+		}
+	}
+	walk(reflect.TypeFor[T](), "")
+	return zeroed
+}
+
+func hasSelectedChild(fields []string, path string) bool {
+	return slices.ContainsFunc(fields, func(f string) bool {
+		return strings.HasPrefix(f, path+".")
+	})
+}
+
+// fieldType returns the type of the field of t at the dotted path of JSON names.
+func fieldType(t reflect.Type, path string) (reflect.Type, error) {
+	ft := t
+	for i, name := range strings.Split(path, ".") {
+		st := structType(ft)
+		if st == nil {
+			parent := strings.Join(strings.Split(path, ".")[:i], ".")
+			return nil, fmt.Errorf("%q isn't a field of %s: %q has no fields", path, t, parent)
+		}
+
+		f, ok := jsonField(st, name)
+		if !ok {
+			return nil, fmt.Errorf("%q isn't a field of %s", path, t)
+		}
+		ft = f.Type
+	}
+	return ft, nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// structType returns the struct type of the elements of t, dereferencing
+// pointers and slices, or nil if they don't have fields in JSON,
+// e.g. time.Time, which is encoded as a string.
+func structType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct ||
+		t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return nil
+	}
+	return t
+}
+
+// jsonField returns the field of the struct type t with the JSON name.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if n, ok := jsonName(f); ok && n == name {
+			return f, true
+		}
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			if et := structType(f.Type); et != nil {
+				if ef, ok := jsonField(et, name); ok {
+					return ef, true
+				}
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// jsonFields returns the JSON names of the fields of the struct type t.
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			if et := structType(f.Type); et != nil {
+				names = append(names, jsonFields(et)...)
+			}
+			continue
+		}
+		if n, ok := jsonName(f); ok {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// jsonName returns the name of f in JSON, if it's encoded.
+func jsonName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
@@ -0,0 +1,63 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectFields(t *testing.T) {
+	for _, fields := range [][]string{
+		{"id", "hostname"},
+		{"ip_addresses.address", "region.slug"},
+		{"plan.pricing.price", "bmc.expires"},
+		{"ip_addresses"},
+	} {
+		_, err := SelectFields[Server](fields...)
+		assert.NoError(t, err, fields)
+	}
+
+	_, err := SelectFields[Server]("hostnme")
+	assert.ErrorContains(t, err, `"hostnme" isn't a field of cherrygo.Server`)
+
+	_, err = SelectFields[Server]("ip_addresses.adress")
+	assert.ErrorContains(t, err, `"ip_addresses.adress"`)
+
+	_, err = SelectFields[Server]("bmc.expires.seconds")
+	assert.ErrorContains(t, err, `"bmc.expires" has no fields`)
+
+	_, err = SelectFields[string]("id")
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustSelectFields[IPAddress]("adress") })
+}
+
+func TestFieldSelectorZeroed(t *testing.T) {
+	s := MustSelectFields[BlockStorage]("id", "attached_to.hostname")
+	assert.Equal(t, []string{
+		"name", "href", "size", "allow_edit_size", "unit", "description",
+		"attached_to.id", "attached_to.href",
+		"vlan_id", "vlan_ip", "initiator", "discovery_ip", "region",
+	}, s.Zeroed())
+
+	assert.Empty(t, MustSelectFields[BlockStorage]().Zeroed())
+}
+
+func TestFieldSelectorOption(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "hostname,ip_addresses.address", r.URL.Query().Get("fields"))
+		_, err := fmt.Fprint(w, `{"hostname": "web"}`)
+		require.NoError(t, err)
+	})
+
+	s := MustSelectFields[Server]("hostname", "ip_addresses.address")
+	srv, _, err := testClient.Servers.Get(t.Context(), 1, nil, s.Option())
+	require.NoError(t, err)
+	assert.Equal(t, "web", srv.Hostname)
+}
@@ -0,0 +1,216 @@
+package cherrygo
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ListFilter filters the resources returned by List and All methods.
+// Conditions the API supports are sent as query parameters, the others
+// are applied to the results, so either way only matching resources are returned.
+// If the call selects fields, e.g. with [Fields], the fields the conditions
+// need are selected as well.
+//
+// Filters are passed to List and All methods with [Filter]. Since
+// some conditions are applied to the results, pages returned by List
+// methods may be shorter than requested, while [Meta.Total] still counts
+// resources that don't match. All methods yield every matching resource.
+type ListFilter interface {
+	// query adds the conditions the API supports to the request.
+	query(req *http.Request)
+}
+
+// itemFilter is a [ListFilter] of resources of type T.
+type itemFilter[T any] interface {
+	ListFilter
+
+	// match reports whether item matches the conditions
+	// that are not sent to the API.
+	match(item T) bool
+}
+
+// Filter applies f to the results of a List or All method of
+// the resources it filters, e.g. [ServerFilter] to [ServersClient.List].
+// Passing it to any other method is an error.
+func Filter(f ListFilter) CallOption {
+	return func(o *callOptions) {
+		o.filter = f
+	}
+}
+
+// ServerFilter filters servers. Status is sent to the API, the other
+// conditions are applied to the results. Zero fields match any server.
+type ServerFilter struct {
+	// Region is the region slug, e.g. "eu_nord_1".
+	Region string
+
+	// Status matches servers with any of the statuses, e.g. "deployed".
+	Status []string
+
+	// Tags matches servers that have all of the tags.
+	Tags map[string]string
+
+	// Plan is the plan slug, e.g. "cloud_vps_1".
+	Plan string
+
+	Hostname string
+}
+
+func (f ServerFilter) query(req *http.Request) {
+	addQuery(req, "status[]", f.Status)
+	selectFields(req, map[string]bool{
+		"region":   f.Region != "",
+		"plan":     f.Plan != "",
+		"hostname": f.Hostname != "",
+		"tags":     len(f.Tags) > 0,
+	})
+}
+
+func (f ServerFilter) match(srv Server) bool {
+	return (f.Region == "" || srv.Region.Slug == f.Region) &&
+		(f.Plan == "" || srv.Plan.Slug == f.Plan) &&
+		(f.Hostname == "" || srv.Hostname == f.Hostname) &&
+		hasTags(srv.Tags, f.Tags)
+}
+
+// IPFilter filters IP addresses. Type is sent to the API, the other
+// conditions are applied to the results. Zero fields match any address.
+type IPFilter struct {
+	// Type matches addresses of any of the types, e.g. "floating-ip".
+	Type []string
+
+	// Family is the address family, 4 or 6.
+	Family int
+
+	// Assigned matches addresses that are, or if false, are not, assigned
+	// to a server, either directly or by being routed to another address.
+	Assigned *bool
+
+	// Region is the region slug, e.g. "eu_nord_1".
+	Region string
+}
+
+func (f IPFilter) query(req *http.Request) {
+	addQuery(req, "type[]", f.Type)
+	selectFields(req, map[string]bool{
+		"address_family": f.Family != 0,
+		"assigned_to":    f.Assigned != nil,
+		"routed_to":      f.Assigned != nil,
+		"region":         f.Region != "",
+	})
+}
+
+func (f IPFilter) match(ip IPAddress) bool {
+	assigned := ip.AssignedTo.ID != 0 || ip.RoutedTo.ID != ""
+	return (f.Family == 0 || ip.AddressFamily == f.Family) &&
+		(f.Assigned == nil || assigned == *f.Assigned) &&
+		(f.Region == "" || ip.Region.Slug == f.Region)
+}
+
+// StorageFilter filters block storages. Its conditions are applied to
+// the results. Zero fields match any storage.
+type StorageFilter struct {
+	// Attached matches storages that are, or if false, are not, attached to a server.
+	Attached *bool
+}
+
+func (f StorageFilter) query(req *http.Request) {
+	selectFields(req, map[string]bool{"attached_to": f.Attached != nil})
+}
+
+func (f StorageFilter) match(storage BlockStorage) bool {
+	return f.Attached == nil || (storage.AttachedTo.ID != 0) == *f.Attached
+}
+
+func addQuery(req *http.Request, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	q := req.URL.Query()
+	for _, v := range values {
+		if !slices.Contains(q[key], v) {
+			q.Add(key, v)
+		}
+	}
+	req.URL.RawQuery = q.Encode()
+}
+
+// selectFields adds the needed fields to the fields selected by req, if any,
+// so conditions applied to the results don't see zero fields.
+func selectFields(req *http.Request, needed map[string]bool) {
+	q := req.URL.Query()
+	if q.Get("fields") == "" {
+		return
+	}
+
+	fields := strings.Split(q.Get("fields"), ",")
+	for _, field := range slices.Sorted(maps.Keys(needed)) {
+		if needed[field] && !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	q.Set("fields", strings.Join(fields, ","))
+	req.URL.RawQuery = q.Encode()
+}
+
+// listFilter returns the filter of callOpts for resources of type T, if any,
+// along with the call options without it, so requests don't reject it.
+func listFilter[T any](callOpts []CallOption) (itemFilter[T], []CallOption, error) {
+	co := newCallOptions(callOpts)
+	if co.filter == nil {
+		return nil, callOpts, nil
+	}
+
+	f, ok := co.filter.(itemFilter[T])
+	if !ok {
+		var zero T
+		return nil, nil, fmt.Errorf("%T can't filter %T resources", co.filter, zero)
+	}
+	return f, append(slices.Clip(callOpts), Filter(nil)), nil
+}
+
+// applyFilter sends the conditions of f the API supports with req.
+func applyFilter(req *http.Request, f ListFilter) {
+	if f != nil {
+		f.query(req)
+	}
+}
+
+// filterItems returns the items that match f.
+func filterItems[T any](items []T, f itemFilter[T]) []T {
+	if f == nil {
+		return items
+	}
+	return slices.DeleteFunc(items, func(item T) bool { return !f.match(item) })
+}
+
+// filterSeq returns an iterator over the items of seq that match f.
+func filterSeq[T any](seq iter.Seq2[T, error], f itemFilter[T]) iter.Seq2[T, error] {
+	if f == nil {
+		return seq
+	}
+
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err == nil && !f.match(item) {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// errSeq returns an iterator that yields err.
+func errSeq[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
@@ -0,0 +1,175 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"deployed"}, r.URL.Query()["status[]"])
+		_, err := fmt.Fprint(w, `[
+			{"id": 1, "hostname": "web", "region": {"slug": "eu_nord_1"}, "plan": {"slug": "cloud_vps_1"}, "tags": {"env": "prod"}},
+			{"id": 2, "hostname": "web", "region": {"slug": "eu_west_1"}, "plan": {"slug": "cloud_vps_1"}, "tags": {"env": "prod"}},
+			{"id": 3, "hostname": "db", "region": {"slug": "eu_nord_1"}, "plan": {"slug": "cloud_vps_1"}, "tags": {"env": "prod"}},
+			{"id": 4, "hostname": "web", "region": {"slug": "eu_nord_1"}, "plan": {"slug": "e5_1620v4"}, "tags": {"env": "prod"}},
+			{"id": 5, "hostname": "web", "region": {"slug": "eu_nord_1"}, "plan": {"slug": "cloud_vps_1"}, "tags": {"env": "dev"}}
+		]`)
+		require.NoError(t, err)
+	})
+
+	servers, _, err := testClient.Servers.List(t.Context(), 1, nil, Filter(ServerFilter{
+		Region:   "eu_nord_1",
+		Status:   []string{"deployed"},
+		Tags:     map[string]string{"env": "prod"},
+		Plan:     "cloud_vps_1",
+		Hostname: "web",
+	}))
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, 1, servers[0].ID)
+}
+
+func TestFilterAllPages(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Header().Set("X-Total-Count", "5")
+
+		// Every page holds one server in eu_nord_1, except the last.
+		pages := map[int]string{
+			0: `[{"id": 1, "region": {"slug": "eu_nord_1"}}, {"id": 2, "region": {"slug": "eu_west_1"}}]`,
+			2: `[{"id": 3, "region": {"slug": "eu_west_1"}}, {"id": 4, "region": {"slug": "eu_nord_1"}}]`,
+			4: `[{"id": 5, "region": {"slug": "eu_west_1"}}]`,
+		}
+		_, err := fmt.Fprint(w, pages[offset])
+		require.NoError(t, err)
+	})
+
+	var ids []int
+	for srv, err := range testClient.Servers.All(t.Context(), 1, &GetOptions{Limit: 2}, Filter(ServerFilter{Region: "eu_nord_1"})) {
+		require.NoError(t, err)
+		ids = append(ids, srv.ID)
+	}
+	assert.Equal(t, []int{1, 4}, ids)
+	assert.Equal(t, 3, requests)
+}
+
+func TestIPFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/projects/1/ips", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"floating-ip"}, r.URL.Query()["type[]"])
+		_, err := fmt.Fprint(w, `[
+			{"id": "a", "address_family": 4, "region": {"slug": "eu_nord_1"}},
+			{"id": "b", "address_family": 4, "region": {"slug": "eu_nord_1"}, "assigned_to": {"id": 1}},
+			{"id": "c", "address_family": 4, "region": {"slug": "eu_nord_1"}, "routed_to": {"id": "x"}},
+			{"id": "d", "address_family": 6, "region": {"slug": "eu_nord_1"}},
+			{"id": "e", "address_family": 4, "region": {"slug": "eu_west_1"}}
+		]`)
+		require.NoError(t, err)
+	})
+
+	unassigned := false
+	ips, _, err := testClient.IPAddresses.List(t.Context(), 1, nil, Filter(IPFilter{
+		Type:     []string{"floating-ip"},
+		Family:   4,
+		Assigned: &unassigned,
+		Region:   "eu_nord_1",
+	}))
+	require.NoError(t, err)
+	require.Len(t, ips, 1)
+	assert.Equal(t, "a", ips[0].ID)
+
+	assigned := true
+	ips, _, err = testClient.IPAddresses.List(t.Context(), 1, nil, Filter(IPFilter{Type: []string{"floating-ip"}, Assigned: &assigned}))
+	require.NoError(t, err)
+	assert.Len(t, ips, 2)
+}
+
+func TestStorageFilter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/projects/1/storages", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `[{"id": 1, "attached_to": {"id": 7}}, {"id": 2}]`)
+		require.NoError(t, err)
+	})
+
+	detached := false
+	storages, _, err := testClient.Storages.List(t.Context(), 1, nil, Filter(StorageFilter{Attached: &detached}))
+	require.NoError(t, err)
+	require.Len(t, storages, 1)
+	assert.Equal(t, 2, storages[0].ID)
+}
+
+func TestFilterMismatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(http.ResponseWriter, *http.Request) {
+		t.Error("request with a mismatched filter was sent")
+	})
+
+	_, _, err := testClient.Servers.List(t.Context(), 1, nil, Filter(IPFilter{}))
+	assert.ErrorContains(t, err, "can't filter")
+
+	for _, err := range testClient.Storages.All(t.Context(), 1, nil, Filter(ServerFilter{})) {
+		assert.ErrorContains(t, err, "can't filter")
+	}
+
+	_, _, err = testClient.Servers.Get(t.Context(), 1, nil, Filter(ServerFilter{}))
+	assert.ErrorContains(t, err, "only supported by List and All")
+}
+
+func TestFilterSelectsFields(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var fields []string
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		_, err := fmt.Fprint(w, `[{"id": 1, "region": {"slug": "eu_nord_1"}}, {"id": 2, "region": {"slug": "eu_west_1"}}]`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/projects/1/ips", func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		_, err := fmt.Fprint(w, `[{"id": "a", "address_family": 4}, {"id": "b", "address_family": 6}]`)
+		require.NoError(t, err)
+	})
+
+	filter := Filter(ServerFilter{Region: "eu_nord_1"})
+
+	servers, _, err := testClient.Servers.List(t.Context(), 1, nil, Fields("id"), filter)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, 1, servers[0].ID)
+
+	servers, _, err = testClient.Servers.List(t.Context(), 1, &GetOptions{Fields: []string{"id", "region"}}, filter)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+
+	servers, _, err = testClient.Servers.List(t.Context(), 1, nil, filter)
+	require.NoError(t, err)
+	assert.Len(t, servers, 1)
+
+	ips, _, err := testClient.IPAddresses.List(t.Context(), 1, nil, MustSelectFields[IPAddress]("id").Option(), Filter(IPFilter{Family: 6}))
+	require.NoError(t, err)
+	require.Len(t, ips, 1)
+	assert.Equal(t, "b", ips[0].ID)
+
+	assert.Equal(t, []string{"id,region", "id,region", "", "id,address_family"}, fields)
+}
@@ -0,0 +1,113 @@
+package cherrygo
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+type idempotencyKeyCtxKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx that makes mutating requests
+// created with it carry key as their idempotency key.
+//
+// Requests with an idempotency key are retried on transient failures,
+// just like idempotent ones. Reuse the same key when repeating a call
+// that should not take effect more than once.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key
+}
+
+// setIdempotencyKey adds an idempotency key header to POST and PATCH requests,
+// taking it from the request context or generating one, if enabled.
+func (c *Client) setIdempotencyKey(req *http.Request) error {
+	if req.Method != http.MethodPost && req.Method != http.MethodPatch {
+		return nil
+	}
+
+	key := idempotencyKeyFromContext(req.Context())
+	if key == "" && c.idempotencyKeys {
+		var err error
+		key, err = newIdempotencyKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+	}
+
+	if key != "" {
+		req.Header.Set(client.IdempotencyKeyHeader, key)
+	}
+	return nil
+}
+
+// contextWithIdempotencyKey returns a copy of ctx with a generated idempotency
+// key, so that all requests of a call share it, unless the call already
+// has one from ctx or callOpts.
+func contextWithIdempotencyKey(ctx context.Context, callOpts []CallOption) (context.Context, error) {
+	if idempotencyKeyFromContext(ctx) != "" || newCallOptions(callOpts).idempotencyKey != "" {
+		return ctx, nil
+	}
+
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return ContextWithIdempotencyKey(ctx, key), nil
+}
+
+// newIdempotencyKey generates a random (version 4) UUID.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// isAmbiguous reports whether err leaves it unknown if the request took
+// effect, i.e. it may have reached the API, but no reliable answer came back.
+func isAmbiguous(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Checked first, since responses of retries given up because of
+	// the deadline also match context.DeadlineExceeded.
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusBadGateway ||
+			apiErr.StatusCode == http.StatusGatewayTimeout
+	}
+
+	// A retry given up because of the deadline leaves the context usable,
+	// unlike one that actually expired.
+	if errors.Is(err, context.Canceled) ||
+		(errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrRetryDeadline)) {
+		return false
+	}
+
+	// The connection could not be established, so nothing was sent.
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return false
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+
+	return true
+}
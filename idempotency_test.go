@@ -0,0 +1,189 @@
+package cherrygo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestIdempotencyKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithIdempotencyKeys())
+	require.NoError(t, err)
+
+	var keys []string
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		body := `{"id": 1}`
+		if r.Method == http.MethodGet {
+			body = `[]`
+		}
+		_, err := fmt.Fprint(w, body)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Create(t.Context(), &CreateServer{ProjectID: 1})
+	require.NoError(t, err)
+	_, _, err = c.Servers.Create(t.Context(), &CreateServer{ProjectID: 1})
+	require.NoError(t, err)
+	_, _, err = c.Servers.Create(ContextWithIdempotencyKey(t.Context(), "my-key"), &CreateServer{ProjectID: 1})
+	require.NoError(t, err)
+	_, _, err = c.Servers.List(t.Context(), 1, nil)
+	require.NoError(t, err)
+
+	require.Len(t, keys, 4)
+	assert.Regexp(t, uuidPattern, keys[0])
+	assert.Regexp(t, uuidPattern, keys[1])
+	assert.NotEqual(t, keys[0], keys[1], "Generated keys should be unique.")
+	assert.Equal(t, "my-key", keys[2])
+	assert.Empty(t, keys[3], "GET requests should not carry idempotency keys.")
+}
+
+func TestIdempotencyKeysDisabledByDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Idempotency-Key"))
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err := testClient.Servers.Create(t.Context(), &CreateServer{ProjectID: 1})
+	require.NoError(t, err)
+}
+
+func TestServer_CreateReconciliation(t *testing.T) {
+	cases := []struct {
+		title       string
+		request     CreateServer
+		listed      []Server
+		wantID      int
+		wantCreates int
+		wantErr     bool
+	}{
+		{
+			title:   "server found",
+			request: CreateServer{ProjectID: 1, Hostname: "web-1"},
+			listed: []Server{
+				{ID: 1, Hostname: "web-1", Created: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+				{ID: 2, Hostname: "web-2", Created: time.Now().Format(time.RFC3339)},
+				{ID: 3, Hostname: "web-1", Created: time.Now().Format(time.RFC3339)},
+			},
+			wantID:      3,
+			wantCreates: 1,
+		},
+		{
+			title:   "server found by tags",
+			request: CreateServer{ProjectID: 1, Tags: &map[string]string{"order": "abc"}},
+			listed: []Server{
+				{ID: 1, Tags: map[string]string{"order": "xyz"}, Created: time.Now().Format(time.RFC3339)},
+				{ID: 2, Tags: map[string]string{"order": "abc", "env": "dev"}, Created: time.Now().Format(time.RFC3339)},
+			},
+			wantID:      2,
+			wantCreates: 1,
+		},
+		{
+			title:   "server not found",
+			request: CreateServer{ProjectID: 1, Hostname: "web-1"},
+			listed: []Server{
+				{ID: 1, Hostname: "web-1", Created: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+			},
+			wantID:      10,
+			wantCreates: 2,
+		},
+		{
+			title:       "not reconcilable",
+			request:     CreateServer{ProjectID: 1},
+			wantCreates: 1,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			setup()
+			defer teardown()
+
+			c, err := NewClient(WithURL(server.URL), WithIdempotencyKeys(), WithMaxRetries(0), WithCreateReconciliation(5*time.Minute))
+			require.NoError(t, err)
+
+			creates := 0
+			var keys []string
+			mux.HandleFunc("POST /v1/projects/1/servers", func(w http.ResponseWriter, r *http.Request) {
+				creates++
+				keys = append(keys, r.Header.Get("Idempotency-Key"))
+				if creates == 1 {
+					w.WriteHeader(http.StatusGatewayTimeout)
+					return
+				}
+				_, err := fmt.Fprint(w, `{"id": 10}`)
+				require.NoError(t, err)
+			})
+			mux.HandleFunc("GET /v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("X-Total-Count", fmt.Sprint(len(tc.listed)))
+				err := json.NewEncoder(w).Encode(tc.listed)
+				require.NoError(t, err)
+			})
+
+			srv, resp, err := c.Servers.Create(t.Context(), &tc.request)
+
+			assert.Equal(t, tc.wantCreates, creates)
+			assert.Regexp(t, uuidPattern, keys[0])
+			for _, key := range keys[1:] {
+				assert.Equal(t, keys[0], key, "Orders should be retried with the same idempotency key.")
+			}
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrServerError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, srv.ID)
+			require.NotNil(t, resp)
+			if tc.wantCreates == 1 {
+				assert.Equal(t, len(tc.listed), resp.Meta.Total, "Reconciled orders should return the list response.")
+			}
+		})
+	}
+}
+
+func TestServer_CreateReconciliationUnderDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithIdempotencyKeys(), WithCreateReconciliation(time.Hour))
+	require.NoError(t, err)
+
+	creates := 0
+	mux.HandleFunc("POST /v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+		creates++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+	lists := 0
+	mux.HandleFunc("GET /v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+		lists++
+		_, err := fmt.Fprintf(w, `[{"id": 7, "hostname": "web-1", "created_at": %q}]`, time.Now().Format(time.RFC3339))
+		require.NoError(t, err)
+	})
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	srv, _, err := c.Servers.Create(ctx, &CreateServer{ProjectID: 1, Hostname: "web-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, srv.ID)
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 1, lists)
+}
@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,7 +12,8 @@ const baseImagePath = "/v1/plans"
 // ImagesService is an interface for interfacing with the the Images endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Images
 type ImagesService interface {
-	List(ctx context.Context, plan string, opts *GetOptions) ([]Image, *Response, error)
+	List(ctx context.Context, plan string, opts *GetOptions, callOpts ...CallOption) ([]Image, *Response, error)
+	All(ctx context.Context, plan string, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Image, error]
 }
 
 // Image holds OS image data.
@@ -28,11 +30,11 @@ type ImagesClient struct {
 }
 
 // List func lists images
-func (i *ImagesClient) List(ctx context.Context, plan string, opts *GetOptions) ([]Image, *Response, error) {
+func (i *ImagesClient) List(ctx context.Context, plan string, opts *GetOptions, callOpts ...CallOption) ([]Image, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%s/images", baseImagePath, plan))
 	var trans []Image
 
-	req, err := i.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := i.client.NewRequest(withOperation(ctx, "Images.List"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -40,3 +42,10 @@ func (i *ImagesClient) List(ctx context.Context, plan string, opts *GetOptions)
 	resp, err := i.client.Do(req, &trans)
 	return trans, resp, err
 }
+
+// All iterates over all images available for plan, fetching pages lazily.
+func (i *ImagesClient) All(ctx context.Context, plan string, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Image, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]Image, *Response, error) {
+		return i.List(ctx, plan, opts, callOpts...)
+	})
+}
@@ -17,21 +17,30 @@ const (
 	defaultExponentialBackoffMultiplier = 2
 )
 
-type requestDoer interface {
+// Doer performs HTTP requests.
+type Doer interface {
 	// Do performs an HTTP request.
 	// May not strictly adhere to RoundTripper/Client semantics.
 	Do(*http.Request) (*http.Response, error)
 }
 
+// Middleware wraps a [Doer] to add behavior around requests.
+type Middleware func(next Doer) Doer
+
 // Client is a wrapper for http.Client that can retry
 // on transient errors, with respect to request idempotency.
 // Safe for concurrent use, just like [net/http.Client].
 type Client struct {
-	maxRetries int
-	backoff    backoff.Func
-	debugDst   io.Writer
+	policy      Policy
+	debugDst    io.Writer
+	bucket      *tokenBucket
+	maxInFlight int
+
+	callMiddleware    []Middleware
+	attemptMiddleware []Middleware
+	retryObservers    []func(RetryEvent)
 
-	requestDoer
+	doer       Doer
 	rootClient *http.Client
 }
 
@@ -41,14 +50,37 @@ type Option func(*Client)
 // WithMaxRetries sets a custom amount of maximum retries.
 func WithMaxRetries(n int) Option {
 	return func(c *Client) {
-		c.maxRetries = n
+		c.policy.MaxRetries = n
 	}
 }
 
 // WithBackoff sets a custom backoff generation function.
 func WithBackoff(b backoff.Func) Option {
 	return func(c *Client) {
-		c.backoff = b
+		c.policy.Backoff = b
+	}
+}
+
+// WithMaxRetryAfter limits the delays after responses with a `Retry-After` header to d,
+// and how long the rate limiter pauses requests.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *Client) {
+		c.policy.MaxRetryAfter = d
+	}
+}
+
+// WithRetryableStatuses sets the response status codes that are retried.
+func WithRetryableStatuses(statuses []int) Option {
+	return func(c *Client) {
+		c.policy.RetryableStatuses = statuses
+	}
+}
+
+// WithRetryConnectionErrors sets whether connection resets and unexpected EOFs
+// are considered transient errors, alongside timeouts.
+func WithRetryConnectionErrors(enabled bool) Option {
+	return func(c *Client) {
+		c.policy.RetryConnectionErrors = enabled
 	}
 }
 
@@ -67,17 +99,58 @@ func WithDebug(w io.Writer) Option {
 	}
 }
 
+// WithRateLimit limits requests to rate per second, allowing bursts of up to burst requests.
+// Every attempt counts towards the limit, so retries are limited as well.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Client) {
+		c.bucket = newTokenBucket(rate, burst)
+	}
+}
+
+// WithMaxInFlight limits the amount of request attempts in flight at once to n.
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) {
+		c.maxInFlight = n
+	}
+}
+
+// WithCallMiddleware adds middleware that wraps the retrier, so it sees
+// every request once, regardless of how many attempts are made.
+// The first middleware is the outermost.
+func WithCallMiddleware(m ...Middleware) Option {
+	return func(c *Client) {
+		c.callMiddleware = append(c.callMiddleware, m...)
+	}
+}
+
+// WithAttemptMiddleware adds middleware that is wrapped by the retrier,
+// so it sees every request attempt. The first middleware is the outermost.
+func WithAttemptMiddleware(m ...Middleware) Option {
+	return func(c *Client) {
+		c.attemptMiddleware = append(c.attemptMiddleware, m...)
+	}
+}
+
+// WithRetryObserver adds a function that is called every time a retry is scheduled,
+// before waiting for the backoff delay. It must not block.
+func WithRetryObserver(fn func(RetryEvent)) Option {
+	return func(c *Client) {
+		c.retryObservers = append(c.retryObservers, fn)
+	}
+}
+
 // New creates a new client.
+//
+// Requests pass through the layers in this order:
+// call middleware, retrier, attempt middleware, limiter, debugger
+// and finally the base HTTP client.
 func New(opts ...Option) *Client {
 	client := Client{
-		maxRetries: defaultMaxRetries,
-		backoff: backoff.RateLimitedExponentialBackoff(
-			backoff.ExponentialBackoffConfig{
-				Base:       defaultExponentialBackoffBase,
-				Cap:        defaultExponentialBackoffCap,
-				Multiplier: defaultExponentialBackoffMultiplier,
-			},
-		),
+		policy: Policy{
+			MaxRetries:        defaultMaxRetries,
+			RetryableStatuses: defaultRetryableStatuses(),
+			MaxRetryAfter:     backoff.DefaultMaxRetryAfter,
+		},
 		rootClient: http.DefaultClient,
 	}
 
@@ -85,19 +158,64 @@ func New(opts ...Option) *Client {
 		opt(&client)
 	}
 
-	var c requestDoer = client.rootClient
+	if client.policy.Backoff == nil {
+		// Built after the options, so it doesn't limit Retry-After
+		// delays more than MaxRetryAfter does.
+		client.policy.Backoff = backoff.RateLimitedExponentialBackoff(
+			backoff.ExponentialBackoffConfig{
+				Base:       defaultExponentialBackoffBase,
+				Cap:        defaultExponentialBackoffCap,
+				Multiplier: defaultExponentialBackoffMultiplier,
+			},
+			backoff.WithMaxRetryAfter(client.policy.MaxRetryAfter),
+		)
+	}
+
+	var c Doer = client.rootClient
 	if client.debugDst != nil {
 		c = &debugger{
-			wrapped: client.rootClient,
+			wrapped: c,
 			dst:     client.debugDst,
 		}
 	}
 
-	client.requestDoer = &retrier{
-		wrapped:    c,
-		maxRetries: client.maxRetries,
-		backoff:    client.backoff,
+	if client.bucket != nil {
+		client.bucket.maxPause = client.policy.MaxRetryAfter
+	}
+
+	if client.bucket != nil || client.maxInFlight > 0 {
+		l := &limiter{
+			wrapped: c,
+			bucket:  client.bucket,
+		}
+		if client.maxInFlight > 0 {
+			l.slots = make(chan struct{}, client.maxInFlight)
+		}
+		c = l
+	}
+
+	c = chain(c, client.attemptMiddleware)
+
+	c = &retrier{
+		wrapped:   c,
+		policy:    client.policy,
+		observers: client.retryObservers,
 	}
 
+	client.doer = chain(c, client.callMiddleware)
+
 	return &client
 }
+
+// Do performs an HTTP request, passing it through all the client layers.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.doer.Do(req)
+}
+
+// chain wraps d with middleware, so that the first middleware is the outermost.
+func chain(d Doer, middleware []Middleware) Doer {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		d = middleware[i](d)
+	}
+	return d
+}
@@ -1,16 +1,19 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httputil"
 	"sync"
+
+	"github.com/cherryservers/cherrygo/v4/internal/redact"
 )
 
 type debugger struct {
-	wrapped requestDoer
+	wrapped Doer
 	dst     io.Writer
 	l       *log.Logger
 
@@ -22,31 +25,21 @@ func (d *debugger) Do(req *http.Request) (*http.Response, error) {
 		d.l = log.New(d.dst, "cherrygo ", log.Default().Flags())
 	})
 
-	// Mask API key.
-	auth := req.Header.Get("Authorization")
-	if auth != "" {
-		req.Header.Set("Authorization", "***")
-	}
-
 	d.l.Printf("\nAPI Endpoint: %v\n", req.URL)
 
-	dump, err := httputil.DumpRequestOut(req, true)
+	dump, err := dumpRequest(req)
 	if err != nil {
 		return nil, err
 	}
 
 	d.l.Printf("\n+++++++++++++REQUEST+++++++++++++\n%s\n+++++++++++++++++++++++++++++++++", string(dump))
 
-	if auth != "" {
-		req.Header.Set("Authorization", auth)
-	}
-
 	resp, err := d.wrapped.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	dump, err = httputil.DumpResponse(resp, true)
+	dump, err = dumpResponse(resp)
 	if err != nil {
 		// RoundTrip must always return err=nil if it obtained a response.
 		dump = fmt.Appendf(nil, "failed to dump response: %v", err)
@@ -55,3 +48,47 @@ func (d *debugger) Do(req *http.Request) (*http.Response, error) {
 	d.l.Printf("\n+++++++++++++RESPONSE+++++++++++++\n%s\n+++++++++++++++++++++++++++++++++", string(dump))
 	return resp, nil
 }
+
+// dumpRequest dumps req with secrets redacted, leaving req intact.
+func dumpRequest(req *http.Request) ([]byte, error) {
+	body, err := peekBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := req.Clone(req.Context())
+	redacted.Header = redact.Header(req.Header)
+	redacted.Body = http.NoBody
+
+	dump, err := httputil.DumpRequestOut(redacted, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(dump, redact.JSON(body)...), nil
+}
+
+// dumpResponse dumps resp with secrets redacted, leaving resp intact.
+func dumpResponse(resp *http.Response) ([]byte, error) {
+	body, err := peekBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	dump, err := httputil.DumpResponse(resp, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(dump, redact.JSON(body)...), nil
+}
+
+// peekBody reads the body and replaces it with an unread copy.
+func peekBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
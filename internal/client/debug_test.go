@@ -40,3 +40,34 @@ func TestDebug(t *testing.T) {
 	assert.Contains(t, string(got), "***")
 	assert.NotContains(t, string(got), "SECRET")
 }
+
+func TestDebugRedactsBodies(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"hostname": "test", "password": "hunter2"}`, string(body))
+
+		_, err = fmt.Fprintf(w, `{"id": 1, "bmc": {"user": "admin", "password": "bmcsecret"}}`)
+		require.NoError(t, err)
+	}))
+	defer ts.Close()
+
+	buf := &bytes.Buffer{}
+
+	client := client.New(client.WithDebug(buf))
+	req, err := http.NewRequest("POST", ts.URL, strings.NewReader(`{"hostname": "test", "password": "hunter2"}`))
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "bmcsecret")
+
+	got := buf.String()
+	assert.Contains(t, got, `"hostname":"test"`)
+	assert.Contains(t, got, `"user":"admin"`)
+	assert.NotContains(t, got, "hunter2")
+	assert.NotContains(t, got, "bmcsecret")
+}
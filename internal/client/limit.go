@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/backoff"
+)
+
+// limiter delays requests so they don't exceed a rate limit and
+// a maximum amount of requests in flight.
+//
+// It adapts to the rate limit headers of responses, pausing all requests
+// when the API reports the limit to be exhausted.
+type limiter struct {
+	wrapped Doer
+
+	// bucket is nil if requests are not rate limited.
+	bucket *tokenBucket
+
+	// slots is nil if requests in flight are not limited.
+	slots chan struct{}
+}
+
+func (l *limiter) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			defer func() { <-l.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := l.wrapped.Do(req)
+	if err == nil && l.bucket != nil {
+		l.bucket.observe(resp)
+	}
+	return resp, err
+}
+
+// tokenBucket is a token bucket rate limiter that refills
+// rate tokens per second, up to burst tokens.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	// pausedUntil is set when the API reports that its rate limit is exhausted.
+	pausedUntil time.Time
+	// maxPause limits how far pausedUntil is set ahead, if it's positive.
+	maxPause time.Duration
+
+	now func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token and returns how long to wait before using it.
+// The bucket may go into debt, which makes later reservations wait longer.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	b.tokens--
+
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if pause := b.pausedUntil.Sub(now); pause > wait {
+		wait = pause
+	}
+	return wait
+}
+
+// cancel returns a reserved token that won't be used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	wait := b.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// observe pauses the bucket if resp reports that the rate limit is exhausted,
+// either with rate limit headers or a 429 status with a Retry-After header.
+// The pause is limited to maxPause, like the retrier limits Retry-After delays.
+func (b *tokenBucket) observe(resp *http.Response) {
+	var until time.Time
+	now := b.now()
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, ok := ParseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), now); ok {
+			until = reset
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := backoff.RetryAfter(resp); ok {
+			if after := now.Add(delay); after.After(until) {
+				until = after
+			}
+		}
+	}
+
+	if until.IsZero() {
+		return
+	}
+	if limit := now.Add(b.maxPause); b.maxPause > 0 && until.After(limit) {
+		until = limit
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+	}
+}
+
+// ParseRateLimitReset parses a rate limit reset header value, which
+// is either a unix timestamp or an amount of seconds until the reset.
+func ParseRateLimitReset(v string, now time.Time) (time.Time, bool) {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, false
+	}
+
+	// Anything larger than a year of seconds is a timestamp.
+	if n > 365*24*60*60 {
+		return time.Unix(n, 0), true
+	}
+	return now.Add(time.Duration(n) * time.Second), true
+}
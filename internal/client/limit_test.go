@@ -0,0 +1,135 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doGet(t *testing.T, ctx context.Context, c *client.Client, url string) error {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer ts.Close()
+
+	c := client.New(client.WithRateLimit(50, 1))
+
+	start := time.Now()
+	for range 5 {
+		require.NoError(t, doGet(t, t.Context(), c, ts.URL))
+	}
+
+	// The first request uses the burst, the rest wait 20ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestRateLimitContextCancellation(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		calls++
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithRateLimit(0.1, 1))
+	require.NoError(t, doGet(t, t.Context(), c, ts.URL))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+
+	err := doGet(t, ctx, c, ts.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRateLimitAdaptsToHeaders(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls == 0 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1")
+		}
+		calls++
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithRateLimit(1000, 10))
+
+	require.NoError(t, doGet(t, t.Context(), c, ts.URL))
+
+	start := time.Now()
+	require.NoError(t, doGet(t, t.Context(), c, ts.URL))
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestRateLimitPauseIsLimited(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls == 0 {
+			w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		calls++
+	}))
+	defer ts.Close()
+
+	c := client.New(
+		client.WithRateLimit(1000, 10),
+		client.WithMaxRetries(0),
+		client.WithMaxRetryAfter(50*time.Millisecond),
+	)
+
+	require.Error(t, doGet(t, t.Context(), c, ts.URL))
+
+	start := time.Now()
+	require.NoError(t, doGet(t, t.Context(), c, ts.URL))
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 40*time.Millisecond)
+	assert.Less(t, elapsed, 5*time.Second)
+}
+
+func TestMaxInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithMaxInFlight(2))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			assert.NoError(t, doGet(t, t.Context(), c, ts.URL))
+		})
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func recordingMiddleware(name string, log *[]string) client.Middleware {
+	return func(next client.Doer) client.Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			*log = append(*log, name)
+			return next.Do(req)
+		})
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls == 0 {
+			w.WriteHeader(503)
+		}
+		calls++
+	}))
+	defer ts.Close()
+
+	var log []string
+	c := client.New(
+		client.WithBackoff(testBackoff()),
+		client.WithCallMiddleware(
+			recordingMiddleware("call 1", &log),
+			recordingMiddleware("call 2", &log),
+		),
+		client.WithAttemptMiddleware(
+			recordingMiddleware("attempt 1", &log),
+			recordingMiddleware("attempt 2", &log),
+		),
+	)
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, []string{
+		"call 1", "call 2",
+		"attempt 1", "attempt 2",
+		"attempt 1", "attempt 2",
+	}, log)
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/backoff"
+)
+
+// Policy configures how failed requests are retried.
+type Policy struct {
+	// MaxRetries is the maximum amount of retries, after the first attempt.
+	MaxRetries int
+
+	// Backoff generates the delays between attempts.
+	Backoff backoff.Func
+
+	// RetryableStatuses are the response status codes that are retried
+	// for idempotent requests.
+	RetryableStatuses []int
+
+	// RetryConnectionErrors makes connection resets and unexpected EOFs
+	// count as transient errors, alongside timeouts.
+	RetryConnectionErrors bool
+
+	// MaxRetryAfter limits the delays after responses with
+	// a `Retry-After` header, whatever Backoff returns.
+	MaxRetryAfter time.Duration
+}
+
+func defaultRetryableStatuses() []int {
+	return []int{
+		http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+}
+
+type policyCtxKey struct{}
+
+// ContextWithPolicy returns a copy of ctx that makes requests
+// apply modify to a copy of the client retry policy.
+func ContextWithPolicy(ctx context.Context, modify func(*Policy)) context.Context {
+	if prev, ok := ctx.Value(policyCtxKey{}).(func(*Policy)); ok {
+		next := modify
+		modify = func(p *Policy) {
+			prev(p)
+			next(p)
+		}
+	}
+	return context.WithValue(ctx, policyCtxKey{}, modify)
+}
+
+// HasContextPolicy reports whether ctx modifies the retry policy, see [ContextWithPolicy].
+func HasContextPolicy(ctx context.Context) bool {
+	_, ok := ctx.Value(policyCtxKey{}).(func(*Policy))
+	return ok
+}
+
+func policyFromContext(ctx context.Context, base Policy) Policy {
+	if modify, ok := ctx.Value(policyCtxKey{}).(func(*Policy)); ok {
+		modify(&base)
+	}
+	return base
+}
+
+// Stats are the statistics of a single logical request,
+// that may have been attempted multiple times.
+type Stats struct {
+	// Attempts is the amount of times the request was sent.
+	Attempts int
+
+	// Backoff is the total time spent waiting between attempts.
+	Backoff time.Duration
+
+	// Cached reports whether the response was served from a cache.
+	Cached bool
+
+	// Coalesced reports whether the response was shared with
+	// an identical request that was already in flight.
+	Coalesced bool
+}
+
+type statsCtxKey struct{}
+
+// StatsFromContext returns the statistics recorder set with [ContextWithStats],
+// or nil if there is none.
+func StatsFromContext(ctx context.Context) *Stats {
+	stats, _ := ctx.Value(statsCtxKey{}).(*Stats)
+	return stats
+}
+
+// ContextWithStats returns a copy of ctx that makes requests
+// record their statistics in stats.
+func ContextWithStats(ctx context.Context, stats *Stats) context.Context {
+	return context.WithValue(ctx, statsCtxKey{}, stats)
+}
+
+func statsFromContext(ctx context.Context) *Stats {
+	if stats := StatsFromContext(ctx); stats != nil {
+		return stats
+	}
+	return &Stats{}
+}
+
+// RetryEvent describes a retry that has been scheduled.
+type RetryEvent struct {
+	// Request is the request that will be retried.
+	Request *http.Request
+
+	// Attempt is the number of the attempt that failed, starting from 1.
+	Attempt int
+
+	// Delay is the backoff delay before the next attempt.
+	Delay time.Duration
+
+	// Err is the reason the attempt failed.
+	Err error
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"slices"
+	"syscall"
 	"time"
 
 	"github.com/cherryservers/cherrygo/v4/backoff"
@@ -16,22 +17,101 @@ import (
 
 const bodyReadLimit = 4096
 
+// IdempotencyKeyHeader is the request header that carries an idempotency key.
+// Requests that have it are considered safe to retry, regardless of their method.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Reasons for giving up on retrying a request, that a [*RetryError]
+// matches with [errors.Is].
+var (
+	// ErrAttemptLimit means the maximum amount of retries was reached.
+	ErrAttemptLimit = errors.New("retry attempt limit reached")
+
+	// ErrRetryBudget means the backoff returned [backoff.Stop],
+	// e.g. because of [backoff.MaxElapsed].
+	ErrRetryBudget = errors.New("retry budget exhausted")
+
+	// ErrRetryDeadline means waiting for the next attempt would pass the
+	// context deadline. Errors with this reason also match [context.DeadlineExceeded].
+	ErrRetryDeadline = errors.New("retry would pass the context deadline")
+)
+
+// RetryError is returned when a request keeps failing and
+// the client gives up retrying it.
+type RetryError struct {
+	// Reason is why the client gave up, e.g. [ErrAttemptLimit].
+	Reason error
+
+	// Attempts is the amount of times the request was sent.
+	Attempts int
+
+	// Delay is the backoff delay the next attempt would have waited for,
+	// if the client gave up because of it.
+	Delay time.Duration
+
+	// Response is the last response received, if any. Its body
+	// is buffered and limited in size, so it remains readable.
+	Response *http.Response
+
+	// Err is the reason the last attempt failed.
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s, last attempt: %v", e.Abort(), e.Err)
+}
+
+// Abort describes why the client gave up, without the last attempt error.
+func (e *RetryError) Abort() string {
+	switch e.Reason {
+	case ErrRetryBudget:
+		return fmt.Sprintf("retry budget exhausted after %d attempts", e.Attempts)
+	case ErrRetryDeadline:
+		return fmt.Sprintf("retry in %v would pass the context deadline, after %d attempts", e.Delay, e.Attempts)
+	}
+	return fmt.Sprintf("max retries %d exceeded", e.Attempts-1)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the reason the client gave up.
+func (e *RetryError) Is(target error) bool {
+	return target == e.Reason || (e.Reason == ErrRetryDeadline && target == context.DeadlineExceeded)
+}
+
 type retrier struct {
-	wrapped    requestDoer
-	maxRetries int
-	backoff    backoff.Func
+	wrapped   Doer
+	policy    Policy
+	observers []func(RetryEvent)
 }
 
 // Do executes requests, retrying unsuccessful ones, when it safe to do so.
 //
-// Requests are passed to the wrapped [requestDoer], which is expected to act like a
+// Requests are passed to the wrapped [Doer], which is expected to act like a
 // [net/http.Client]. If the response status code indicates success or is unsafe to
 // retry, returns that response with a nil error. If the request context
-// expires or the retry attempt limit is reached, the response will be nil and
-// an error will be returned.
+// expires or the client gives up retrying, the response will be nil and
+// an error will be returned. In the latter case, the error is a [*RetryError],
+// e.g. when the next delay would pass the context deadline.
+//
+// The client retry policy can be overridden per request with [ContextWithPolicy].
 func (r *retrier) Do(req *http.Request) (*http.Response, error) {
 	ctx := req.Context()
+	policy := policyFromContext(ctx, r.policy)
+	stats := statsFromContext(ctx)
 	var lastErr error
+	var lastResp *http.Response
+	giveUp := func(reason error, delay time.Duration) error {
+		return &RetryError{
+			Reason:   reason,
+			Attempts: stats.Attempts,
+			Delay:    delay,
+			Response: lastResp,
+			Err:      lastErr,
+		}
+	}
 
 	// The original request is discarded, make sure its body is closed.
 	defer func() {
@@ -40,7 +120,7 @@ func (r *retrier) Do(req *http.Request) (*http.Response, error) {
 		}
 	}()
 
-	for attempts := 0; attempts < r.maxRetries+1; attempts++ {
+	for attempts := 0; attempts < policy.MaxRetries+1; attempts++ {
 		clone, err := cloneRequest(ctx, req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to clone request: %w", err)
@@ -49,31 +129,62 @@ func (r *retrier) Do(req *http.Request) (*http.Response, error) {
 		if ctx.Err() != nil {
 			return nil, ctx.Err()
 		}
+		stats.Attempts++
 		resp, err := r.wrapped.Do(clone)
 		if err != nil {
-			if !isTransient(err) || !isIdempotent(clone.Method) {
+			if !isTransient(err, policy.RetryConnectionErrors) || !isIdempotent(clone) {
 				return nil, err
 			}
 			lastErr = err
+			lastResp = nil
 		} else {
-			if isSuccessful(resp.StatusCode) || !safeToRetry(resp.StatusCode, clone.Method) {
+			if isSuccessful(resp.StatusCode) || !safeToRetry(resp.StatusCode, clone, policy.RetryableStatuses) {
 				return resp, nil
 			}
 			lastErr = fmt.Errorf("bad status: %q", resp.Status)
 
 			// Try to drain and close the body, so the connection is freed.
-			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, bodyReadLimit))
+			// Keep what was read, in case this turns out to be the last attempt.
+			buf := &bytes.Buffer{}
+			_, _ = io.Copy(buf, io.LimitReader(resp.Body, bodyReadLimit))
 			_ = resp.Body.Close()
+			resp.Body = io.NopCloser(buf)
+			lastResp = resp
+		}
+
+		// Don't wait if there won't be another attempt.
+		if attempts == policy.MaxRetries {
+			break
+		}
+
+		delay := policy.Backoff(attempts, resp)
+		if delay == backoff.Stop {
+			return nil, giveUp(ErrRetryBudget, 0)
+		}
+		if _, ok := backoff.RetryAfter(resp); ok && policy.MaxRetryAfter > 0 {
+			delay = min(delay, policy.MaxRetryAfter)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+			return nil, giveUp(ErrRetryDeadline, delay)
+		}
+		for _, observe := range r.observers {
+			observe(RetryEvent{
+				Request: req,
+				Attempt: attempts + 1,
+				Delay:   delay,
+				Err:     lastErr,
+			})
 		}
 
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-time.After(r.backoff(attempts, resp)):
+		case <-time.After(delay):
+			stats.Backoff += delay
 		}
 	}
 
-	return nil, fmt.Errorf("max retries %d exceeded, last attempt: %w", r.maxRetries, lastErr)
+	return nil, giveUp(ErrAttemptLimit, 0)
 }
 
 func cloneRequest(ctx context.Context, req *http.Request) (*http.Request, error) {
@@ -110,16 +221,24 @@ func cloneRequest(ctx context.Context, req *http.Request) (*http.Request, error)
 	return c, nil
 }
 
-func isTransient(err error) bool {
-	if err != nil {
-		var netErr net.Error
-		if errors.As(err, &netErr) {
-			if netErr.Timeout() {
-				return true
-			}
+func isTransient(err error, connectionErrors bool) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return true
 		}
 	}
 
+	if connectionErrors {
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, io.EOF) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+
 	return false
 }
 
@@ -127,22 +246,18 @@ func isSuccessful(status int) bool {
 	return status >= 200 && status < 300
 }
 
-func isIdempotent(method string) bool {
-	return method != http.MethodConnect &&
-		method != http.MethodPost &&
-		method != http.MethodPatch
-}
-
-func safeToRetry(status int, method string) bool {
-	retryable := []int{
-		http.StatusRequestTimeout,
-		http.StatusTooManyRequests,
-		http.StatusBadGateway,
-		http.StatusServiceUnavailable,
-		http.StatusGatewayTimeout,
+func isIdempotent(req *http.Request) bool {
+	if req.Header.Get(IdempotencyKeyHeader) != "" {
+		return req.Method != http.MethodConnect
 	}
 
-	if isIdempotent(method) {
+	return req.Method != http.MethodConnect &&
+		req.Method != http.MethodPost &&
+		req.Method != http.MethodPatch
+}
+
+func safeToRetry(status int, req *http.Request, retryable []int) bool {
+	if isIdempotent(req) {
 		return slices.Contains(retryable, status)
 	}
 	return false
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -35,6 +36,7 @@ type retryTestCase struct {
 	title          string
 	maxRetries     int
 	method         string
+	header         http.Header
 	status         int
 	fn             http.HandlerFunc
 	reqCtx         context.Context
@@ -68,6 +70,9 @@ func testRetry(t *testing.T, tc retryTestCase) {
 
 		req, err := http.NewRequestWithContext(tc.reqCtx, tc.method, ts.URL, &reqSpy)
 		require.NoError(t, err)
+		for k, v := range tc.header {
+			req.Header[k] = v
+		}
 
 		resp, err := client.Do(req)
 
@@ -176,6 +181,38 @@ func TestRetry(t *testing.T) {
 	}
 }
 
+func TestRetryIdempotencyKey(t *testing.T) {
+	for _, method := range []string{"POST", "PATCH"} {
+		calls := 0
+		header := make(http.Header)
+		header.Set(client.IdempotencyKeyHeader, "key")
+
+		testRetry(t, retryTestCase{
+			title:      fmt.Sprintf("retry with idempotency key: method %q", method),
+			maxRetries: 5,
+			method:     method,
+			header:     header,
+			status:     503,
+			reqCtx:     t.Context(),
+			backoffFn:  testBackoff(),
+			fn: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "key", r.Header.Get(client.IdempotencyKeyHeader))
+				if calls == 0 {
+					w.WriteHeader(503)
+					_, _ = fmt.Fprint(w, "error")
+				} else {
+					_, _ = fmt.Fprint(w, "test")
+				}
+				calls++
+			},
+			wantCalls:      2,
+			wantErr:        false,
+			wantRespDrains: 1,
+			wantBody:       "test",
+		})
+	}
+}
+
 type errorSpyRoundTripper struct {
 	err   error
 	calls int
@@ -293,3 +330,264 @@ func TestRetryContextCancellation(t *testing.T) {
 		wantBody:       "",
 	})
 }
+
+func TestRetryPolicyOverride(t *testing.T) {
+	noRetries := client.ContextWithPolicy(t.Context(), func(p *client.Policy) {
+		p.MaxRetries = 0
+	})
+
+	testRetry(t, retryTestCase{
+		title:      "disable retries with request context",
+		maxRetries: 5,
+		method:     "GET",
+		status:     503,
+		fn: func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(503)
+			_, _ = fmt.Fprint(w, "error")
+		},
+		reqCtx:         noRetries,
+		backoffFn:      testBackoff(),
+		wantCalls:      1,
+		wantErr:        true,
+		wantRespDrains: 1,
+	})
+
+	calls := 0
+	onlyTeapots := client.ContextWithPolicy(t.Context(), func(p *client.Policy) {
+		p.RetryableStatuses = []int{http.StatusTeapot}
+	})
+
+	testRetry(t, retryTestCase{
+		title:      "override retryable statuses with request context",
+		maxRetries: 5,
+		method:     "GET",
+		status:     418,
+		fn: func(w http.ResponseWriter, _ *http.Request) {
+			if calls == 0 {
+				w.WriteHeader(418)
+				_, _ = fmt.Fprint(w, "error")
+			} else {
+				_, _ = fmt.Fprint(w, "test")
+			}
+			calls++
+		},
+		reqCtx:         onlyTeapots,
+		backoffFn:      testBackoff(),
+		wantCalls:      2,
+		wantErr:        false,
+		wantRespDrains: 1,
+		wantBody:       "test",
+	})
+}
+
+func TestRetryStats(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls < 2 {
+			w.WriteHeader(503)
+		}
+		calls++
+	}))
+	defer ts.Close()
+
+	var constantBackoff backoff.Func = func(_ int, _ *http.Response) time.Duration {
+		return time.Millisecond
+	}
+
+	c := client.New(client.WithBackoff(constantBackoff))
+
+	stats := &client.Stats{}
+	req, err := http.NewRequestWithContext(client.ContextWithStats(t.Context(), stats), "GET", ts.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, 3, stats.Attempts)
+	assert.Equal(t, 2*time.Millisecond, stats.Backoff)
+}
+
+func TestRetryConnectionErrors(t *testing.T) {
+	cases := []struct {
+		title     string
+		enabled   bool
+		err       error
+		wantCalls int
+	}{
+		{
+			title:     "don't retry connection reset by default",
+			err:       syscall.ECONNRESET,
+			wantCalls: 1,
+		},
+		{
+			title:     "retry connection reset",
+			enabled:   true,
+			err:       syscall.ECONNRESET,
+			wantCalls: 3,
+		},
+		{
+			title:     "retry unexpected EOF",
+			enabled:   true,
+			err:       io.ErrUnexpectedEOF,
+			wantCalls: 3,
+		},
+	}
+
+	for _, td := range cases {
+		t.Run(td.title, func(t *testing.T) {
+			rtSpy := errorSpyRoundTripper{
+				err: td.err,
+			}
+
+			c := client.New(
+				client.WithBackoff(testBackoff()),
+				client.WithHTTPClient(&http.Client{Transport: &rtSpy}),
+				client.WithMaxRetries(2),
+				client.WithRetryConnectionErrors(td.enabled),
+			)
+
+			req, err := http.NewRequest("GET", "fake-url", nil)
+			require.NoError(t, err)
+
+			resp, err := c.Do(req)
+
+			assert.Error(t, err)
+			assert.Nil(t, resp)
+			assert.Equal(t, td.wantCalls, rtSpy.calls)
+		})
+	}
+}
+
+func TestRetryErrorKeepsLastResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(429)
+		_, _ = fmt.Fprint(w, `{"message": "slow down"}`)
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithBackoff(testBackoff()), client.WithMaxRetries(2))
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := c.Do(req)
+	require.Nil(t, resp)
+
+	var retryErr *client.RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 3, retryErr.Attempts)
+	require.NotNil(t, retryErr.Response)
+	assert.Equal(t, 429, retryErr.Response.StatusCode)
+
+	body, err := io.ReadAll(retryErr.Response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"message": "slow down"}`, string(body))
+}
+
+func TestRetryBackoffStop(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(503)
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithBackoff(backoff.MaxAttempts(backoff.Constant(time.Millisecond), 2)))
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", ts.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Do(req)
+	var retryErr *client.RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 2, retryErr.Attempts)
+	assert.Equal(t, 2, calls)
+	assert.ErrorIs(t, err, client.ErrRetryBudget)
+	assert.ErrorContains(t, err, "retry budget exhausted after 2 attempts")
+}
+
+func TestRetryDeadline(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(503)
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithBackoff(backoff.Constant(time.Hour)))
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = c.Do(req)
+	assert.Less(t, time.Since(start), time.Second, "waited for a retry past the deadline")
+
+	var retryErr *client.RetryError
+	require.ErrorAs(t, err, &retryErr)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, time.Hour, retryErr.Delay)
+	assert.Equal(t, 503, retryErr.Response.StatusCode)
+	assert.ErrorIs(t, err, client.ErrRetryDeadline)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, client.ErrAttemptLimit)
+	assert.ErrorContains(t, err, "retry in 1h0m0s would pass the context deadline, after 1 attempts")
+}
+
+func TestRetryAttemptLimitReason(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer ts.Close()
+
+	c := client.New(client.WithMaxRetries(1), client.WithBackoff(backoff.Constant(time.Millisecond)))
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", ts.URL, nil)
+	require.NoError(t, err)
+
+	_, err = c.Do(req)
+	assert.ErrorIs(t, err, client.ErrAttemptLimit)
+	assert.NotErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "max retries 1 exceeded")
+}
+
+func TestRetryMaxRetryAfter(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(429)
+		}
+	}))
+	defer ts.Close()
+
+	for name, opts := range map[string][]client.Option{
+		"default backoff": {client.WithMaxRetryAfter(time.Millisecond)},
+		"custom backoff": {
+			client.WithMaxRetryAfter(time.Millisecond),
+			client.WithBackoff(backoff.RateLimitedExponentialBackoff(backoff.ExponentialBackoffConfig{
+				Base: time.Millisecond, Cap: time.Millisecond, Multiplier: 2,
+			}, backoff.WithMaxRetryAfter(time.Hour))),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls = 0
+			c := client.New(opts...)
+
+			stats := &client.Stats{}
+			req, err := http.NewRequestWithContext(client.ContextWithStats(t.Context(), stats), "GET", ts.URL, nil)
+			require.NoError(t, err)
+
+			resp, err := c.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, 2, stats.Attempts)
+			assert.Equal(t, time.Millisecond, stats.Backoff)
+		})
+	}
+}
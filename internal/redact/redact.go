@@ -0,0 +1,93 @@
+// Package redact masks secrets in request and response dumps.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Mask replaces redacted values.
+const Mask = "***"
+
+// Fields are the JSON object keys whose values are redacted, at any depth.
+// They cover server, BMC and backup method passwords, private SSH keys
+// of backup methods and server user data.
+var Fields = []string{"password", "ssh_key", "user_data"}
+
+// Headers are the headers whose values are redacted.
+var Headers = []string{"Authorization"}
+
+// JSON returns a copy of body with the values of sensitive fields replaced by [Mask].
+// Bodies that are not JSON are returned as is.
+func JSON(body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	if !redactValue(v) {
+		return body
+	}
+
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return body
+	}
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		buf.Truncate(buf.Len() - 1)
+	}
+	return buf.Bytes()
+}
+
+// redactValue masks sensitive fields of v in place and reports whether any were found.
+func redactValue(v any) bool {
+	found := false
+
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isSensitive(key) && value != nil {
+				v[key] = Mask
+				found = true
+				continue
+			}
+			found = redactValue(value) || found
+		}
+	case []any:
+		for _, value := range v {
+			found = redactValue(value) || found
+		}
+	}
+
+	return found
+}
+
+func isSensitive(key string) bool {
+	for _, f := range Fields {
+		if strings.EqualFold(key, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Header returns a copy of h with the values of sensitive headers replaced by [Mask].
+func Header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range Headers {
+		if h.Get(name) != "" {
+			h.Set(name, Mask)
+		}
+	}
+	return h
+}
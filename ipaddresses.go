@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,13 +12,14 @@ const baseIPPath = "/v1/ips"
 // IPAddressesService is an interface for interfacing with the the Server endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Ip-Addresses
 type IPAddressesService interface {
-	List(ctx context.Context, projectID int, opts *GetOptions) ([]IPAddress, *Response, error)
-	Get(ctx context.Context, ipID string, opts *GetOptions) (IPAddress, *Response, error)
-	Create(ctx context.Context, projectID int, request *CreateIPAddress) (IPAddress, *Response, error)
-	Remove(ctx context.Context, ipID string) (*Response, error)
-	Update(ctx context.Context, ipID string, request *UpdateIPAddress) (IPAddress, *Response, error)
-	Assign(ctx context.Context, ipID string, request *AssignIPAddress) (IPAddress, *Response, error)
-	Unassign(ctx context.Context, ipID string) (*Response, error)
+	List(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]IPAddress, *Response, error)
+	All(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[IPAddress, error]
+	Get(ctx context.Context, ipID string, opts *GetOptions, callOpts ...CallOption) (IPAddress, *Response, error)
+	Create(ctx context.Context, projectID int, request *CreateIPAddress, callOpts ...CallOption) (IPAddress, *Response, error)
+	Remove(ctx context.Context, ipID string, callOpts ...CallOption) (*Response, error)
+	Update(ctx context.Context, ipID string, request *UpdateIPAddress, callOpts ...CallOption) (IPAddress, *Response, error)
+	Assign(ctx context.Context, ipID string, request *AssignIPAddress, callOpts ...CallOption) (IPAddress, *Response, error)
+	Unassign(ctx context.Context, ipID string, callOpts ...CallOption) (*Response, error)
 }
 
 // IPAddress data.
@@ -101,26 +103,51 @@ type AssignIPAddress struct {
 	RoutedTo string `json:"routed_to,omitempty"`
 }
 
-// List func lists ip addresses
-func (i *IPsClient) List(ctx context.Context, projectID int, opts *GetOptions) ([]IPAddress, *Response, error) {
-	path := opts.WithQuery(fmt.Sprintf("%s/%d/ips", baseProjectPath, projectID))
+// List func lists ip addresses. See [IPFilter] for filtering them.
+func (i *IPsClient) List(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]IPAddress, *Response, error) {
+	filter, callOpts, err := listFilter[IPAddress](callOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trans, resp, err := i.list(withOperation(ctx, "IPAddresses.List"), projectID, opts, filter, callOpts...)
+	return filterItems(trans, filter), resp, err
+}
+
+// All iterates over all project IP addresses, fetching pages lazily.
+func (i *IPsClient) All(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[IPAddress, error] {
+	filter, callOpts, err := listFilter[IPAddress](callOpts)
+	if err != nil {
+		return errSeq[IPAddress](err)
+	}
+
+	// Filter the iterator rather than the pages,
+	// so short filtered pages don't end pagination.
+	return filterSeq(Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]IPAddress, *Response, error) {
+		return i.list(withOperation(ctx, "IPAddresses.List"), projectID, opts, filter, callOpts...)
+	}), filter)
+}
+
+func (i *IPsClient) list(ctx context.Context, projectID int, opts *GetOptions, filter ListFilter, callOpts ...CallOption) ([]IPAddress, *Response, error) {
+	path := opts.WithQuery(fmt.Sprintf("%s/%d/ips", baseProjectPath, i.client.projectID(projectID)))
 	var trans []IPAddress
 
-	req, err := i.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := i.client.NewRequest(ctx, http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
+	applyFilter(req, filter)
 
 	resp, err := i.client.Do(req, &trans)
 	return trans, resp, err
 }
 
 // Get IP address.
-func (i *IPsClient) Get(ctx context.Context, ipID string, opts *GetOptions) (IPAddress, *Response, error) {
+func (i *IPsClient) Get(ctx context.Context, ipID string, opts *GetOptions, callOpts ...CallOption) (IPAddress, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%s", baseIPPath, ipID))
 	var trans IPAddress
 
-	req, err := i.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := i.client.NewRequest(withOperation(ctx, "IPAddresses.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return IPAddress{}, nil, err
 	}
@@ -130,11 +157,17 @@ func (i *IPsClient) Get(ctx context.Context, ipID string, opts *GetOptions) (IPA
 }
 
 // Create function orders new floating IP address
-func (i *IPsClient) Create(ctx context.Context, projectID int, request *CreateIPAddress) (IPAddress, *Response, error) {
+func (i *IPsClient) Create(ctx context.Context, projectID int, request *CreateIPAddress, callOpts ...CallOption) (IPAddress, *Response, error) {
+	if request != nil && i.client.shouldValidate(callOpts) {
+		if err := request.Validate(); err != nil {
+			return IPAddress{}, nil, err
+		}
+	}
+
 	var trans IPAddress
-	path := fmt.Sprintf("%s/%d/ips", baseProjectPath, projectID)
+	path := fmt.Sprintf("%s/%d/ips", baseProjectPath, i.client.projectID(projectID))
 
-	req, err := i.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := i.client.NewRequest(withOperation(ctx, "IPAddresses.Create"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return IPAddress{}, nil, err
 	}
@@ -144,11 +177,11 @@ func (i *IPsClient) Create(ctx context.Context, projectID int, request *CreateIP
 }
 
 // Update function updates existing IP address
-func (i *IPsClient) Update(ctx context.Context, ipID string, request *UpdateIPAddress) (IPAddress, *Response, error) {
+func (i *IPsClient) Update(ctx context.Context, ipID string, request *UpdateIPAddress, callOpts ...CallOption) (IPAddress, *Response, error) {
 	var trans IPAddress
 	path := fmt.Sprintf("%s/%s", baseIPPath, ipID)
 
-	req, err := i.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := i.client.NewRequest(withOperation(ctx, "IPAddresses.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return IPAddress{}, nil, err
 	}
@@ -158,10 +191,10 @@ func (i *IPsClient) Update(ctx context.Context, ipID string, request *UpdateIPAd
 }
 
 // Remove function removes existing project IP address
-func (i *IPsClient) Remove(ctx context.Context, ipID string) (*Response, error) {
+func (i *IPsClient) Remove(ctx context.Context, ipID string, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%s", baseIPPath, ipID)
 
-	req, err := i.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := i.client.NewRequest(withOperation(ctx, "IPAddresses.Remove"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -171,11 +204,11 @@ func (i *IPsClient) Remove(ctx context.Context, ipID string) (*Response, error)
 }
 
 // Assign IP address.
-func (i *IPsClient) Assign(ctx context.Context, ipID string, request *AssignIPAddress) (IPAddress, *Response, error) {
+func (i *IPsClient) Assign(ctx context.Context, ipID string, request *AssignIPAddress, callOpts ...CallOption) (IPAddress, *Response, error) {
 	var trans IPAddress
 	path := fmt.Sprintf("%s/%s", baseIPPath, ipID)
 
-	req, err := i.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := i.client.NewRequest(withOperation(ctx, "IPAddresses.Assign"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return IPAddress{}, nil, err
 	}
@@ -185,13 +218,13 @@ func (i *IPsClient) Assign(ctx context.Context, ipID string, request *AssignIPAd
 }
 
 // Unassign IP address.
-func (i *IPsClient) Unassign(ctx context.Context, ipID string) (*Response, error) {
+func (i *IPsClient) Unassign(ctx context.Context, ipID string, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%s", baseIPPath, ipID)
 	request := UpdateIPAddress{
 		TargetedTo: "0",
 	}
 
-	req, err := i.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := i.client.NewRequest(withOperation(ctx, "IPAddresses.Unassign"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return nil, err
 	}
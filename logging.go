@@ -0,0 +1,96 @@
+package cherrygo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// WithLogger enables structured logging to l. Events are logged at these levels:
+//
+//   - Debug: every request attempt and its response, and polling iterations.
+//   - Warn: scheduled retries and attempts that failed without a response.
+//
+// Request and response bodies are never logged; use [WithDebug] for full dumps.
+// Logging is disabled by default.
+func WithLogger(l *slog.Logger) ClientOpt {
+	return func(c *options) error {
+		if l == nil {
+			return errors.New("logger must not be nil")
+		}
+
+		c.logger = l
+		c.clientOpts = append(c.clientOpts,
+			client.WithAttemptMiddleware(loggingMiddleware(l)),
+			client.WithRetryObserver(func(ev client.RetryEvent) {
+				attrs := append(requestAttrs(ev.Request),
+					slog.Int("attempt", ev.Attempt),
+					slog.Duration("delay", ev.Delay),
+				)
+				if ev.Err != nil {
+					attrs = append(attrs, slog.String("error", ev.Err.Error()))
+				}
+				l.LogAttrs(ev.Request.Context(), slog.LevelWarn, "retry scheduled", attrs...)
+			}),
+		)
+		return nil
+	}
+}
+
+func requestAttrs(req *http.Request) []slog.Attr {
+	route := requestRoute(req)
+	return []slog.Attr{
+		slog.String("operation", route.operation),
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.String()),
+		slog.String("credentials", authorizationIdentity(req.Header.Get("Authorization"))),
+	}
+}
+
+func loggingMiddleware(l *slog.Logger) client.Middleware {
+	return func(next client.Doer) client.Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			attrs := requestAttrs(req)
+			if stats := client.StatsFromContext(ctx); stats != nil {
+				attrs = append(attrs, slog.Int("attempt", stats.Attempts))
+			}
+
+			l.LogAttrs(ctx, slog.LevelDebug, "request", attrs...)
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				l.LogAttrs(ctx, slog.LevelWarn, "request failed", attrs...)
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			l.LogAttrs(ctx, slog.LevelDebug, "response", attrs...)
+			return resp, nil
+		})
+	}
+}
+
+// discardLogger is the default logger, which drops all records.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+func (c *Client) observePoll(ctx context.Context, info PollInfo) {
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "poll",
+		slog.String("operation", info.Operation),
+		slog.Int("iteration", info.Iteration),
+		slog.String("status", info.Status),
+		slog.Bool("done", info.Done),
+	)
+
+	if c.metrics != nil {
+		c.metrics.PollIteration(info)
+	}
+}
@@ -0,0 +1,133 @@
+package cherrygo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var r map[string]any
+		require.NoError(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithLogger(logger),
+		WithRetryBackoff(func(int, *http.Response) time.Duration { return time.Millisecond }),
+	)
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/383531", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 383531, "password": "hunter2"}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Get(t.Context(), 383531, nil)
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "hunter2")
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 5)
+
+	var msgs []string
+	for _, r := range records {
+		msgs = append(msgs, r["msg"].(string))
+		assert.Equal(t, "Servers.Get", r["operation"])
+		assert.Equal(t, http.MethodGet, r["method"])
+	}
+	assert.Equal(t, []string{"request", "response", "retry scheduled", "request", "response"}, msgs)
+
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, float64(503), records[1]["status"])
+	assert.Equal(t, "WARN", records[2]["level"])
+	assert.Equal(t, float64(2), records[3]["attempt"])
+	assert.Equal(t, float64(200), records[4]["status"])
+}
+
+func TestLoggerPoll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithLogger(logger),
+		WithPollBackoff(func(int, *http.Response) time.Duration { return 0 }),
+	)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/servers/123", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": 123, "status": "deployed"}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.WaitForStatus(t.Context(), 123, StatusDeployed)
+	require.NoError(t, err)
+
+	records := logRecords(t, buf)
+	require.Len(t, records, 3)
+	assert.Equal(t, "poll", records[2]["msg"])
+	assert.Equal(t, "Servers.WaitForStatus", records[2]["operation"])
+	assert.Equal(t, "deployed", records[2]["status"])
+	assert.Equal(t, true, records[2]["done"])
+}
+
+func TestDebugRedactsSecrets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := &bytes.Buffer{}
+	c, err := NewClient(WithURL(server.URL), WithAPIKey("secret-api-key"), WithDebug(buf))
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, err := fmt.Fprint(w, `{"id": 1, "bmc": {"user": "admin", "password": "bmc-secret"}}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Create(t.Context(), &CreateServer{
+		ProjectID: 1,
+		Hostname:  "test",
+		UserData:  "user-data-secret",
+	})
+	require.NoError(t, err)
+
+	got := buf.String()
+	assert.Contains(t, got, `"hostname":"test"`)
+	for _, secret := range []string{"secret-api-key", "bmc-secret", "user-data-secret"} {
+		assert.NotContains(t, got, secret)
+	}
+}
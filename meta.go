@@ -0,0 +1,104 @@
+package cherrygo
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// RateLimit is the rate limit budget reported by the API.
+type RateLimit struct {
+	// Limit is the amount of requests allowed in a window.
+	Limit int
+
+	// Remaining is the amount of requests left in the current window.
+	Remaining int
+
+	// Reset is when the current window ends, or zero if unknown.
+	Reset time.Time
+}
+
+// parseRateLimit returns the rate limit of the X-RateLimit-* headers,
+// or nil if the limit or the remaining amount is missing.
+func parseRateLimit(h http.Header, now time.Time) *RateLimit {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return nil
+	}
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+
+	rl := &RateLimit{Limit: limit, Remaining: remaining}
+	if reset, ok := client.ParseRateLimitReset(h.Get("X-RateLimit-Reset"), now); ok {
+		rl.Reset = reset
+	}
+	return rl
+}
+
+// parseLinks returns the next and prev URLs of the Link headers, e.g.
+//
+//	Link: <https://api.cherryservers.com/v1/projects/1/servers?offset=20>; rel="next"
+//
+// Targets may contain commas, so links are split at commas after their parameters.
+func parseLinks(h http.Header) (next, prev string) {
+	for _, header := range h.Values("Link") {
+		rest := header
+		for {
+			rest = strings.TrimLeft(rest, " \t,")
+			if !strings.HasPrefix(rest, "<") {
+				break
+			}
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				break
+			}
+
+			target := rest[1:end]
+			var params []string
+			params, rest = cutLinkParams(rest[end+1:])
+
+			for _, param := range params {
+				key, value, _ := strings.Cut(param, "=")
+				if !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for rel := range strings.FieldsSeq(strings.Trim(strings.TrimSpace(value), `"`)) {
+					switch strings.ToLower(rel) {
+					case "next":
+						next = target
+					case "prev", "previous":
+						prev = target
+					}
+				}
+			}
+		}
+	}
+	return next, prev
+}
+
+// cutLinkParams splits the parameters of a link from s, up to the comma that
+// ends the link, and returns them with the links that follow. Separators in
+// quoted values don't count.
+func cutLinkParams(s string) (params []string, rest string) {
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == ';' && !quoted:
+			params = append(params, s[start:i])
+			start = i + 1
+		case c == ',' && !quoted:
+			return append(params, s[start:i]), s[i+1:]
+		}
+	}
+	return append(params, s[start:]), ""
+}
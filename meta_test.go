@@ -0,0 +1,132 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Request-ID", "req-123")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		w.Header().Set("Date", date.Format(http.TimeFormat))
+		w.Header().Add("Link", `<https://api.cherryservers.com/v1/projects/1/servers?offset=40>; rel="next",`+
+			` <https://api.cherryservers.com/v1/projects/1/servers?offset=0>; rel="prev first"`)
+		_, err := fmt.Fprint(w, `[]`)
+		require.NoError(t, err)
+	})
+
+	_, resp, err := testClient.Servers.List(t.Context(), 1, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "req-123", resp.RequestID)
+	assert.Equal(t, &RateLimit{Limit: 100, Remaining: 42, Reset: reset}, resp.RateLimit)
+	assert.Equal(t, "https://api.cherryservers.com/v1/projects/1/servers?offset=40", resp.NextPage)
+	assert.Equal(t, "https://api.cherryservers.com/v1/projects/1/servers?offset=0", resp.PrevPage)
+	assert.True(t, date.Equal(resp.Date))
+	assert.False(t, resp.Retried)
+	assert.False(t, resp.Cached)
+}
+
+func TestResponseMetaDefaults(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header()["Date"] = nil
+		_, err := fmt.Fprint(w, `{}`)
+		require.NoError(t, err)
+	})
+
+	_, resp, err := testClient.Servers.Get(t.Context(), 1, nil, RequestID("mine"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "mine", resp.RequestID)
+	assert.Nil(t, resp.RateLimit)
+	assert.Empty(t, resp.NextPage)
+	assert.Empty(t, resp.PrevPage)
+	assert.True(t, resp.Date.IsZero())
+}
+
+func TestResponseMetaRetried(t *testing.T) {
+	setup()
+	defer teardown()
+
+	attempts := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{}`)
+		require.NoError(t, err)
+	})
+
+	_, resp, err := testClient.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+	assert.True(t, resp.Retried)
+	assert.Equal(t, 2, resp.Attempts)
+}
+
+func TestParseLinks(t *testing.T) {
+	cases := []struct {
+		title    string
+		header   []string
+		wantNext string
+		wantPrev string
+	}{
+		{
+			title:    "comma in target",
+			header:   []string{`<https://api.cherryservers.com/v1/servers?fields=id,name&offset=20>; rel="next", <https://api.cherryservers.com/v1/servers?fields=id,name&offset=0>; rel=prev`},
+			wantNext: "https://api.cherryservers.com/v1/servers?fields=id,name&offset=20",
+			wantPrev: "https://api.cherryservers.com/v1/servers?fields=id,name&offset=0",
+		},
+		{
+			title:    "separators in quoted parameter",
+			header:   []string{`<https://example.com/a>; title="a, b; c"; rel="next"`},
+			wantNext: "https://example.com/a",
+		},
+		{
+			title:    "several headers",
+			header:   []string{`<https://example.com/next>; rel="next"`, `<https://example.com/prev>; rel="previous"`},
+			wantNext: "https://example.com/next",
+			wantPrev: "https://example.com/prev",
+		},
+		{
+			title:  "malformed",
+			header: []string{`https://example.com/next; rel="next"`, `<https://example.com/next; rel="next"`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			next, prev := parseLinks(http.Header{"Link": tc.header})
+			assert.Equal(t, tc.wantNext, next)
+			assert.Equal(t, tc.wantPrev, prev)
+		})
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Now()
+
+	rl := parseRateLimit(http.Header{"X-Ratelimit-Limit": {"100"}, "X-Ratelimit-Remaining": {"0"}}, now)
+	assert.Equal(t, &RateLimit{Limit: 100}, rl)
+
+	assert.Nil(t, parseRateLimit(http.Header{"X-Ratelimit-Limit": {"100"}}, now), "A missing remaining amount shouldn't be reported as 0.")
+	assert.Nil(t, parseRateLimit(http.Header{"X-Ratelimit-Remaining": {"42"}}, now), "A missing limit shouldn't be reported as 0.")
+}
@@ -0,0 +1,159 @@
+package cherrygo
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// Metrics receives instrumentation callbacks from the client.
+//
+// Implementations must be safe for concurrent use and must not block.
+// See the github.com/cherryservers/cherrygo/v4/cherryprom module
+// for a Prometheus implementation.
+type Metrics interface {
+	// RequestStarted is called when a logical call starts.
+	RequestStarted(info RequestInfo)
+
+	// RequestFinished is called when a logical call finishes, after all retries.
+	RequestFinished(info RequestInfo, result RequestResult)
+
+	// RetryScheduled is called when a failed attempt is going to be retried.
+	RetryScheduled(info RequestInfo, retry RetryInfo)
+
+	// PollIteration is called on every iteration of a polling
+	// operation, e.g. [ServersClient.WaitForStatus].
+	PollIteration(info PollInfo)
+}
+
+// RequestInfo identifies an API request for instrumentation.
+type RequestInfo struct {
+	// Method is the HTTP method.
+	Method string
+
+	// Endpoint is the templated request path, e.g. /v1/servers/{server_id},
+	// so it can be used as a low cardinality label. It is "other" for paths
+	// of requests made with [Client.NewRequest] that match no known endpoint.
+	Endpoint string
+
+	// Operation is the name of the service method that made the request,
+	// e.g. Servers.Get.
+	Operation string
+}
+
+// RequestResult is the outcome of a logical call.
+type RequestResult struct {
+	// StatusCode is the response status code, zero if no response was received.
+	StatusCode int
+
+	// Err is the transport error, if any. API errors are reported by StatusCode.
+	Err error
+
+	// Duration is the time the call took, including retries.
+	Duration time.Duration
+
+	// Attempts is the amount of times the request was sent.
+	Attempts int
+}
+
+// RetryInfo describes a scheduled retry.
+type RetryInfo struct {
+	// Attempt is the number of the attempt that failed, starting from 1.
+	Attempt int
+
+	// Delay is the backoff delay before the next attempt.
+	Delay time.Duration
+
+	// Err is the reason the attempt failed.
+	Err error
+}
+
+// PollInfo describes an iteration of a polling operation.
+type PollInfo struct {
+	// Operation is the name of the polling service method, e.g. Servers.WaitForStatus.
+	Operation string
+
+	// Iteration is the number of the iteration, starting from 1.
+	Iteration int
+
+	// Status is the status observed on this iteration.
+	Status string
+
+	// Done reports whether polling stops after this iteration.
+	Done bool
+}
+
+// WithMetrics enables instrumentation callbacks to m.
+func WithMetrics(m Metrics) ClientOpt {
+	return func(c *options) error {
+		if m == nil {
+			return errors.New("metrics must not be nil")
+		}
+
+		c.metrics = m
+		c.clientOpts = append(c.clientOpts,
+			client.WithCallMiddleware(metricsMiddleware(m)),
+			client.WithRetryObserver(func(ev client.RetryEvent) {
+				m.RetryScheduled(newRequestInfo(ev.Request), RetryInfo{
+					Attempt: ev.Attempt,
+					Delay:   ev.Delay,
+					Err:     ev.Err,
+				})
+			}),
+		)
+		return nil
+	}
+}
+
+// otherEndpoint is the endpoint of requests that match no known route,
+// which keeps their raw paths out of metric labels.
+const otherEndpoint = "other"
+
+func newRequestInfo(req *http.Request) RequestInfo {
+	route := requestRoute(req)
+	endpoint := otherEndpoint
+	if route.matched {
+		endpoint = route.templatedPath()
+	}
+	return RequestInfo{
+		Method:    req.Method,
+		Endpoint:  endpoint,
+		Operation: route.operation,
+	}
+}
+
+func metricsMiddleware(m Metrics) client.Middleware {
+	return func(next client.Doer) client.Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			info := newRequestInfo(req)
+			m.RequestStarted(info)
+
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			result := RequestResult{
+				Duration: time.Since(start),
+				Err:      err,
+			}
+			if resp != nil {
+				result.StatusCode = resp.StatusCode
+			}
+
+			var retryErr *client.RetryError
+			if errors.As(err, &retryErr) && retryErr.Response != nil {
+				// Retries were exhausted, report the final status.
+				result.StatusCode = retryErr.Response.StatusCode
+				result.Err = nil
+			}
+
+			if stats := client.StatsFromContext(req.Context()); stats != nil {
+				result.Attempts = stats.Attempts
+			}
+
+			m.RequestFinished(info, result)
+			return resp, err
+		})
+	}
+}
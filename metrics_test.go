@@ -0,0 +1,177 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type finishedRequest struct {
+	info   RequestInfo
+	result RequestResult
+}
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	started  []RequestInfo
+	finished []finishedRequest
+	retries  []RetryInfo
+	polls    []PollInfo
+}
+
+func (m *recordingMetrics) RequestStarted(info RequestInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, info)
+}
+
+func (m *recordingMetrics) RequestFinished(info RequestInfo, result RequestResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished = append(m.finished, finishedRequest{info, result})
+}
+
+func (m *recordingMetrics) RetryScheduled(_ RequestInfo, retry RetryInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries = append(m.retries, retry)
+}
+
+func (m *recordingMetrics) PollIteration(info PollInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.polls = append(m.polls, info)
+}
+
+func TestMetrics(t *testing.T) {
+	setup()
+	defer teardown()
+
+	m := &recordingMetrics{}
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithMetrics(m),
+		WithRetryBackoff(func(int, *http.Response) time.Duration { return time.Millisecond }),
+	)
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/383531", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 383531}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Get(t.Context(), 383531, nil)
+	require.NoError(t, err)
+
+	want := RequestInfo{
+		Method:    http.MethodGet,
+		Endpoint:  "/v1/servers/{server_id}",
+		Operation: "Servers.Get",
+	}
+	assert.Equal(t, []RequestInfo{want}, m.started)
+
+	require.Len(t, m.finished, 1)
+	assert.Equal(t, want, m.finished[0].info)
+	assert.Equal(t, http.StatusOK, m.finished[0].result.StatusCode)
+	assert.Equal(t, 2, m.finished[0].result.Attempts)
+	assert.NoError(t, m.finished[0].result.Err)
+	assert.Positive(t, m.finished[0].result.Duration)
+
+	require.Len(t, m.retries, 1)
+	assert.Equal(t, 1, m.retries[0].Attempt)
+	assert.Equal(t, time.Millisecond, m.retries[0].Delay)
+}
+
+func TestMetricsUnknownEndpoint(t *testing.T) {
+	setup()
+	defer teardown()
+
+	m := &recordingMetrics{}
+	c, err := NewClient(WithURL(server.URL), WithMetrics(m))
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/unknown/42", func(http.ResponseWriter, *http.Request) {})
+
+	req, err := c.NewRequest(t.Context(), http.MethodGet, "/v1/unknown/42", nil)
+	require.NoError(t, err)
+	_, err = c.Do(req, nil)
+	require.NoError(t, err)
+
+	want := RequestInfo{Method: http.MethodGet, Endpoint: "other", Operation: http.MethodGet}
+	assert.Equal(t, []RequestInfo{want}, m.started)
+}
+
+func TestMetricsRetriesExhausted(t *testing.T) {
+	setup()
+	defer teardown()
+
+	m := &recordingMetrics{}
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithMetrics(m),
+		WithMaxRetries(1),
+		WithRetryBackoff(func(int, *http.Response) time.Duration { return 0 }),
+	)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/regions", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, _, err = c.Regions.List(t.Context(), nil)
+	require.Error(t, err)
+
+	require.Len(t, m.finished, 1)
+	assert.Equal(t, "Regions.List", m.finished[0].info.Operation)
+	assert.Equal(t, http.StatusBadGateway, m.finished[0].result.StatusCode)
+	assert.Equal(t, 2, m.finished[0].result.Attempts)
+	assert.NoError(t, m.finished[0].result.Err)
+}
+
+func TestMetricsPollIteration(t *testing.T) {
+	setup()
+	defer teardown()
+
+	m := &recordingMetrics{}
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithMetrics(m),
+		WithPollBackoff(func(int, *http.Response) time.Duration { return 0 }),
+	)
+	require.NoError(t, err)
+
+	polls := 0
+	mux.HandleFunc("/v1/servers/123", func(w http.ResponseWriter, _ *http.Request) {
+		polls++
+		status := "deploying"
+		if polls > 1 {
+			status = "deployed"
+		}
+		_, err := fmt.Fprintf(w, `{"id": 123, "status": %q}`, status)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.WaitForStatus(t.Context(), 123, StatusDeployed)
+	require.NoError(t, err)
+
+	assert.Equal(t, []PollInfo{
+		{Operation: "Servers.WaitForStatus", Iteration: 1, Status: "deploying"},
+		{Operation: "Servers.WaitForStatus", Iteration: 2, Status: "deployed", Done: true},
+	}, m.polls)
+}
+
+func TestWithMetricsNil(t *testing.T) {
+	_, err := NewClient(WithMetrics(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package cherrygo
+
+import (
+	"net/http"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// Doer performs HTTP requests. It is the building block of the client request pipeline.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter that allows using ordinary functions as a [Doer].
+type DoerFunc func(*http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a [Doer] to add behavior around requests, e.g. to inject headers,
+// collect metrics or inject faults. Middleware must call next to pass the request on,
+// or return a response or error of its own.
+type Middleware func(next Doer) Doer
+
+func (m Middleware) internal() client.Middleware {
+	return func(next client.Doer) client.Doer {
+		return m(next)
+	}
+}
+
+func internalMiddleware(m []Middleware) []client.Middleware {
+	im := make([]client.Middleware, 0, len(m))
+	for _, mw := range m {
+		im = append(im, mw.internal())
+	}
+	return im
+}
+
+// WithMiddleware adds middleware that runs once per logical call,
+// i.e. outside of the retry loop, so it sees the final outcome of a request,
+// after all retries. The first middleware is the outermost.
+//
+// Requests pass through the pipeline in this order:
+//
+//  1. Middleware added with WithMiddleware.
+//  2. The retrier.
+//  3. Middleware added with [WithAttemptMiddleware].
+//  4. The rate limiter, see [WithRateLimit].
+//  5. The debug logger, see [WithDebug].
+//  6. The HTTP client, see [WithHTTPClient].
+func WithMiddleware(m ...Middleware) ClientOpt {
+	return func(c *options) error {
+		c.clientOpts = append(c.clientOpts, client.WithCallMiddleware(internalMiddleware(m)...))
+		return nil
+	}
+}
+
+// WithAttemptMiddleware adds middleware that runs once per request attempt,
+// i.e. inside the retry loop, so it sees every retry. Errors and responses it
+// returns are subject to the retry policy. The first middleware is the outermost.
+//
+// See [WithMiddleware] for the full request pipeline order.
+func WithAttemptMiddleware(m ...Middleware) ClientOpt {
+	return func(c *options) error {
+		c.clientOpts = append(c.clientOpts, client.WithAttemptMiddleware(internalMiddleware(m)...))
+		return nil
+	}
+}
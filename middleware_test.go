@@ -0,0 +1,82 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func headerMiddleware(key, value string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set(key, value)
+			return next.Do(req)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var calls, attempts int
+	countCalls := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return next.Do(req)
+		})
+	}
+	countAttempts := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return next.Do(req)
+		})
+	}
+
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithRetryBackoff(func(int, *http.Response) time.Duration { return time.Millisecond }),
+		WithMiddleware(countCalls, headerMiddleware("X-Test", "injected")),
+		WithAttemptMiddleware(countAttempts),
+	)
+	require.NoError(t, err)
+
+	served := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "injected", r.Header.Get("X-Test"))
+		served++
+		if served == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	setup()
+	defer teardown()
+
+	failing := func(Doer) Doer {
+		return DoerFunc(func(*http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("injected fault")
+		})
+	}
+
+	c, err := NewClient(WithURL(server.URL), WithMiddleware(failing))
+	require.NoError(t, err)
+
+	_, _, err = c.Servers.Get(t.Context(), 1, nil)
+	assert.ErrorContains(t, err, "injected fault")
+}
@@ -0,0 +1,79 @@
+package cherrygo
+
+import (
+	"context"
+	"iter"
+	"reflect"
+)
+
+const defaultPageSize = 100
+
+// PageFunc fetches a single page of results, as selected by the
+// Limit and Offset fields of opts.
+type PageFunc[T any] func(ctx context.Context, opts *GetOptions) ([]T, *Response, error)
+
+// Paginate returns an iterator over all the results of a paginated list request.
+//
+// Pages are fetched lazily with fetch, as the iterator is advanced. opts is copied,
+// its Offset is used as the starting point and its Limit as the page size,
+// defaulting to 100 if unset. Iteration ends when the total reported by the API
+// is reached, a short page is returned, or a page starts with the same item as
+// the previous one, i.e. the endpoint ignores the pagination parameters.
+//
+// If a page can't be fetched, or ctx is done, the error is yielded
+// with a zero value and iteration stops.
+func Paginate[T any](ctx context.Context, opts *GetOptions, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var page GetOptions
+		if opts != nil {
+			page = *opts
+		}
+		if page.Limit <= 0 {
+			page.Limit = defaultPageSize
+		}
+
+		var first T
+		for fetched := false; ; fetched = true {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			items, resp, err := fetch(ctx, &page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			// The endpoint returned the same page again, so its
+			// pagination parameters are ignored.
+			if fetched && len(items) > 0 && reflect.DeepEqual(items[0], first) {
+				return
+			}
+			if len(items) > 0 {
+				first = items[0]
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			page.Offset += len(items)
+			if len(items) == 0 || len(items) < page.Limit {
+				return
+			}
+			// The endpoint ignores pagination parameters, so the
+			// whole collection has been returned in one go.
+			if len(items) > page.Limit {
+				return
+			}
+			if resp != nil && resp.Total > 0 && page.Offset >= resp.Total {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package cherrygo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pagedServers(t *testing.T, total int) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		page := []Server{}
+		for id := offset; id < min(offset+limit, total); id++ {
+			page = append(page, Server{ID: id})
+		}
+
+		w.Header().Set("X-Total-Count", strconv.Itoa(total))
+		err = json.NewEncoder(w).Encode(page)
+		require.NoError(t, err)
+	}
+}
+
+func TestServer_All(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	handler := pagedServers(t, 7)
+	mux.HandleFunc("GET /v1/projects/123/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler(w, r)
+	})
+
+	var got []int
+	for srv, err := range testClient.Servers.All(t.Context(), 123, &GetOptions{Limit: 3}) {
+		require.NoError(t, err)
+		got = append(got, srv.ID)
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, got)
+	assert.Equal(t, 3, requests)
+}
+
+func TestPaginateStopsEarly(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	handler := pagedServers(t, 100)
+	mux.HandleFunc("GET /v1/projects/123/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler(w, r)
+	})
+
+	opts := &GetOptions{Limit: 2}
+	var got []int
+	for srv, err := range testClient.Servers.All(t.Context(), 123, opts) {
+		require.NoError(t, err)
+		got = append(got, srv.ID)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	assert.Equal(t, []int{0, 1, 2}, got)
+	assert.Equal(t, 2, requests, "Pages should be fetched lazily.")
+	assert.Equal(t, 0, opts.Offset, "Options should not be mutated.")
+}
+
+func TestPaginatePageError(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	pages := 0
+
+	fetch := func(_ context.Context, opts *GetOptions) ([]int, *Response, error) {
+		pages++
+		if opts.Offset >= 4 {
+			return nil, nil, fetchErr
+		}
+		return []int{opts.Offset, opts.Offset + 1}, nil, nil
+	}
+
+	var got []int
+	var gotErr error
+	for v, err := range Paginate(t.Context(), &GetOptions{Limit: 2}, fetch) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, v)
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3}, got)
+	assert.ErrorIs(t, gotErr, fetchErr)
+	assert.Equal(t, 3, pages)
+}
+
+func TestPaginateContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+
+	fetch := func(_ context.Context, opts *GetOptions) ([]int, *Response, error) {
+		cancel()
+		return []int{opts.Offset, opts.Offset + 1}, nil, nil
+	}
+
+	var got []int
+	var gotErr error
+	for v, err := range Paginate(ctx, &GetOptions{Limit: 2}, fetch) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, v)
+	}
+
+	assert.Equal(t, []int{0, 1}, got)
+	assert.ErrorIs(t, gotErr, context.Canceled)
+}
+
+func TestPaginateUnpaginatedEndpoint(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("GET /v1/regions", func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, err := fmt.Fprint(w, `[{"id": 1}, {"id": 2}, {"id": 3}]`)
+		require.NoError(t, err)
+	})
+
+	var got []int
+	for r, err := range testClient.Regions.All(t.Context(), &GetOptions{Limit: 2}) {
+		require.NoError(t, err)
+		got = append(got, r.ID)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, got)
+	assert.Equal(t, 1, requests)
+}
+
+func TestPaginateIgnoredPagination(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("GET /v1/regions", func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, err := fmt.Fprint(w, `[{"id": 1}, {"id": 2}]`)
+		require.NoError(t, err)
+	})
+
+	var got []int
+	for r, err := range testClient.Regions.All(t.Context(), &GetOptions{Limit: 2}) {
+		require.NoError(t, err)
+		got = append(got, r.ID)
+	}
+
+	assert.Equal(t, []int{1, 2}, got)
+	assert.Equal(t, 2, requests)
+}
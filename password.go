@@ -0,0 +1,201 @@
+package cherrygo
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Cherry Servers password rules. Passwords must be at least 8 characters
+// long and include a letter, a capital letter that is not the first
+// character and a number that is not the last character.
+// They can't include any of [PasswordForbiddenCharacters].
+const (
+	PasswordMinLength = 8
+
+	// PasswordForbiddenCharacters can't be used in passwords.
+	PasswordForbiddenCharacters = "'\"`!$%&;#"
+
+	// PasswordSymbols are the symbols that can be used in passwords,
+	// i.e. the printable ASCII symbols that aren't forbidden.
+	PasswordSymbols = "()*+,-./:<=>?@[\\]^_{|}~"
+)
+
+// PasswordViolation is a password rule that a password breaks.
+type PasswordViolation string
+
+// Password rule violations reported by [ValidatePassword].
+const (
+	PasswordTooShort           PasswordViolation = "must be at least 8 characters long"
+	PasswordNoLetter           PasswordViolation = "must include a letter"
+	PasswordNoCapital          PasswordViolation = "must include a capital letter that is not the first character"
+	PasswordNoDigit            PasswordViolation = "must include a number that is not the last character"
+	PasswordForbiddenCharacter PasswordViolation = "must not include any of " + PasswordForbiddenCharacters
+)
+
+// PasswordError lists the rules a password breaks.
+type PasswordError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = string(v)
+	}
+	return "invalid password: " + strings.Join(msgs, "; ")
+}
+
+// ValidatePassword checks password against the Cherry Servers password rules.
+// It returns a [*PasswordError] with every rule the password breaks, or nil.
+func ValidatePassword(password string) error {
+	var violations []PasswordViolation
+	if len(password) < PasswordMinLength {
+		violations = append(violations, PasswordTooShort)
+	}
+
+	var letter, capital, digit bool
+	for i, c := range password {
+		switch {
+		case c >= 'a' && c <= 'z':
+			letter = true
+		case c >= 'A' && c <= 'Z':
+			letter = true
+			capital = capital || i > 0
+		case c >= '0' && c <= '9':
+			digit = digit || i < len(password)-1
+		}
+	}
+	if !letter {
+		violations = append(violations, PasswordNoLetter)
+	}
+	if !capital {
+		violations = append(violations, PasswordNoCapital)
+	}
+	if !digit {
+		violations = append(violations, PasswordNoDigit)
+	}
+	if strings.ContainsAny(password, PasswordForbiddenCharacters) {
+		violations = append(violations, PasswordForbiddenCharacter)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PasswordError{Violations: violations}
+}
+
+// PasswordOption configures [GeneratePassword].
+type PasswordOption func(*passwordOptions)
+
+type passwordOptions struct {
+	length            int
+	symbols           string
+	excludeLookalikes bool
+}
+
+// PasswordLength sets the length of generated passwords,
+// at least [PasswordMinLength]. Defaults to 20.
+func PasswordLength(n int) PasswordOption {
+	return func(o *passwordOptions) {
+		o.length = n
+	}
+}
+
+// PasswordWithSymbols makes generated passwords include symbols,
+// picked from symbols, which must be a subset of [PasswordSymbols].
+func PasswordWithSymbols(symbols string) PasswordOption {
+	return func(o *passwordOptions) {
+		o.symbols = symbols
+	}
+}
+
+// PasswordExcludeLookalikes leaves characters that are easily confused
+// when read, like 0 and O or 1, l and I, out of generated passwords.
+func PasswordExcludeLookalikes() PasswordOption {
+	return func(o *passwordOptions) {
+		o.excludeLookalikes = true
+	}
+}
+
+// passwordLookalikes are the characters left out by [PasswordExcludeLookalikes].
+const passwordLookalikes = "0OoIl1|"
+
+// GeneratePassword generates a password that matches Cherry Servers secure password
+// criteria in a cryptographically secure way, see [ValidatePassword].
+// By default it generates a 20 character long password of letters and numbers.
+func GeneratePassword(opts ...PasswordOption) (string, error) {
+	o := passwordOptions{length: 20}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.length < PasswordMinLength {
+		return "", fmt.Errorf("password length must be at least %d, got %d", PasswordMinLength, o.length)
+	}
+	if i := strings.IndexFunc(o.symbols, func(r rune) bool { return !strings.ContainsRune(PasswordSymbols, r) }); i >= 0 {
+		return "", fmt.Errorf("%q can't be used as a password symbol, allowed symbols are %s", o.symbols[i:i+1], PasswordSymbols)
+	}
+
+	charset := func(s string) string {
+		if o.excludeLookalikes {
+			return strings.Map(func(r rune) rune {
+				if strings.ContainsRune(passwordLookalikes, r) {
+					return -1
+				}
+				return r
+			}, s)
+		}
+		return s
+	}
+	var (
+		lowercase = charset("abcdefghijklmnopqrstuvwxyz")
+		uppercase = charset("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+		digits    = charset("0123456789")
+		symbols   = charset(o.symbols)
+		all       = lowercase + uppercase + digits + symbols
+	)
+
+	// Draw a character of every required set, fill up the rest from
+	// all sets and shuffle, so no position has a predictable set.
+	required := []string{lowercase, uppercase, digits}
+	if symbols != "" {
+		required = append(required, symbols)
+	}
+	password := make([]byte, o.length)
+	for i := range password {
+		set := all
+		if i < len(required) {
+			set = required[i]
+		}
+		c, err := randIndex(len(set))
+		if err != nil {
+			return "", err
+		}
+		password[i] = set[c]
+	}
+
+	// Shuffle again if the only capital ended up first
+	// or the only digit last, which the rules forbid.
+	for {
+		for i := len(password) - 1; i > 0; i-- {
+			j, err := randIndex(i + 1)
+			if err != nil {
+				return "", err
+			}
+			password[i], password[j] = password[j], password[i]
+		}
+		if ValidatePassword(string(password)) == nil {
+			return string(password), nil
+		}
+	}
+}
+
+// randIndex returns a cryptographically secure random number in [0, n).
+func randIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,79 @@
+package cherrygo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePassword(t *testing.T) {
+	assert.NoError(t, ValidatePassword("abcD3fgh"))
+	assert.NoError(t, ValidatePassword("a(B)c-d_3~"))
+	assert.NoError(t, ValidatePassword("abc D3fgh"))
+	assert.NoError(t, ValidatePassword("abcD3fghé"))
+
+	for password, violations := range map[string][]PasswordViolation{
+		"aB3c":      {PasswordTooShort},
+		"12345678":  {PasswordNoLetter, PasswordNoCapital},
+		"Abcde3fg":  {PasswordNoCapital},
+		"abcDefgh3": {PasswordNoDigit},
+		"abcD3fg#h": {PasswordForbiddenCharacter},
+	} {
+		var perr *PasswordError
+		require.ErrorAs(t, ValidatePassword(password), &perr, password)
+		assert.Equal(t, violations, perr.Violations, password)
+	}
+
+	assert.EqualError(t, ValidatePassword("ab"),
+		"invalid password: must be at least 8 characters long; "+
+			"must include a capital letter that is not the first character; "+
+			"must include a number that is not the last character")
+}
+
+func TestGeneratePasswordOptions(t *testing.T) {
+	for range 200 {
+		pw, err := GeneratePassword(PasswordLength(12), PasswordWithSymbols("-_@"), PasswordExcludeLookalikes())
+		require.NoError(t, err)
+
+		assert.Len(t, pw, 12)
+		assert.NoError(t, ValidatePassword(pw))
+		assert.True(t, strings.ContainsAny(pw, "-_@"), pw)
+		assert.False(t, strings.ContainsAny(pw, passwordLookalikes), pw)
+	}
+
+	_, err := GeneratePassword(PasswordLength(7))
+	assert.ErrorContains(t, err, "at least 8")
+
+	_, err = GeneratePassword(PasswordWithSymbols("-!"))
+	assert.ErrorContains(t, err, `"!" can't be used`)
+}
+
+func TestGeneratePasswordValidates(t *testing.T) {
+	for range 200 {
+		pw, err := GeneratePassword(PasswordWithSymbols(PasswordSymbols))
+		require.NoError(t, err)
+		assert.NoError(t, ValidatePassword(pw))
+	}
+}
+
+func TestGeneratePasswordShuffles(t *testing.T) {
+	// Without shuffling, the first characters would always be
+	// a lowercase letter, a capital letter and a digit.
+	firsts := make(map[string]bool)
+	for range 200 {
+		pw, err := GeneratePassword(PasswordLength(8))
+		require.NoError(t, err)
+
+		first := "lower"
+		switch c := pw[0]; {
+		case c >= 'A' && c <= 'Z':
+			first = "upper"
+		case c >= '0' && c <= '9':
+			first = "digit"
+		}
+		firsts[first] = true
+	}
+	assert.Len(t, firsts, 3, "The first character should be of any set.")
+}
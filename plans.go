@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -14,11 +15,14 @@ const (
 // PlansService is an interface for interfacing with the Plan endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Plans
 type PlansService interface {
-	List(ctx context.Context, teamID int, opts *GetOptions) ([]Plan, *Response, error)
-	GetBySlug(ctx context.Context, slug string, opts *GetOptions) (Plan, *Response, error)
-	GetByID(ctx context.Context, id int, opts *GetOptions) (Plan, *Response, error)
-	ListPrebuiltPlans(ctx context.Context, basePlan, region string, opts *GetOptions) ([]PrebuiltPlan, *Response, error)
-	ListPrebuiltTeamPlans(ctx context.Context, basePlan, region string, teamID int, opts *GetOptions) ([]PrebuiltPlan, *Response, error)
+	List(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) ([]Plan, *Response, error)
+	All(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Plan, error]
+	GetBySlug(ctx context.Context, slug string, opts *GetOptions, callOpts ...CallOption) (Plan, *Response, error)
+	GetByID(ctx context.Context, id int, opts *GetOptions, callOpts ...CallOption) (Plan, *Response, error)
+	ListPrebuiltPlans(ctx context.Context, basePlan, region string, opts *GetOptions, callOpts ...CallOption) ([]PrebuiltPlan, *Response, error)
+	AllPrebuiltPlans(ctx context.Context, basePlan, region string, opts *GetOptions, callOpts ...CallOption) iter.Seq2[PrebuiltPlan, error]
+	ListPrebuiltTeamPlans(ctx context.Context, basePlan, region string, teamID int, opts *GetOptions, callOpts ...CallOption) ([]PrebuiltPlan, *Response, error)
+	AllPrebuiltTeamPlans(ctx context.Context, basePlan, region string, teamID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[PrebuiltPlan, error]
 }
 
 // Plan data.
@@ -117,7 +121,7 @@ type PlansClient struct {
 }
 
 // List func lists plans
-func (p *PlansClient) List(ctx context.Context, teamID int, opts *GetOptions) ([]Plan, *Response, error) {
+func (p *PlansClient) List(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) ([]Plan, *Response, error) {
 	basePath := basePlanPath
 	if teamID != 0 {
 		basePath = fmt.Sprintf("%s/%d/plans", teamPlanPath, teamID)
@@ -126,7 +130,7 @@ func (p *PlansClient) List(ctx context.Context, teamID int, opts *GetOptions) ([
 	path := opts.WithQuery(basePath)
 	var trans []Plan
 
-	req, err := p.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := p.client.NewRequest(withOperation(ctx, "Plans.List"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -135,10 +139,17 @@ func (p *PlansClient) List(ctx context.Context, teamID int, opts *GetOptions) ([
 	return trans, resp, err
 }
 
-func (p *PlansClient) get(ctx context.Context, path string) (Plan, *Response, error) {
+// All iterates over all plans, fetching pages lazily.
+func (p *PlansClient) All(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Plan, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]Plan, *Response, error) {
+		return p.List(ctx, teamID, opts, callOpts...)
+	})
+}
+
+func (p *PlansClient) get(ctx context.Context, path string, callOpts ...CallOption) (Plan, *Response, error) {
 	var trans Plan
 
-	req, err := p.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := p.client.NewRequest(ctx, http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return Plan{}, nil, err
 	}
@@ -148,20 +159,20 @@ func (p *PlansClient) get(ctx context.Context, path string) (Plan, *Response, er
 }
 
 // GetByID retrieves server plan by ID.
-func (p *PlansClient) GetByID(ctx context.Context, id int, opts *GetOptions) (Plan, *Response, error) {
+func (p *PlansClient) GetByID(ctx context.Context, id int, opts *GetOptions, callOpts ...CallOption) (Plan, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", basePlanPath, id))
 
-	return p.get(ctx, path)
+	return p.get(withOperation(ctx, "Plans.GetByID"), path, callOpts...)
 }
 
 // GetBySlug retrieves server plan by slug.
-func (p *PlansClient) GetBySlug(ctx context.Context, slug string, opts *GetOptions) (Plan, *Response, error) {
+func (p *PlansClient) GetBySlug(ctx context.Context, slug string, opts *GetOptions, callOpts ...CallOption) (Plan, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%s", basePlanPath, slug))
 
-	return p.get(ctx, path)
+	return p.get(withOperation(ctx, "Plans.GetBySlug"), path, callOpts...)
 }
 
-func (p *PlansClient) listPrebuiltPlans(ctx context.Context, path, region string, opts *GetOptions) ([]PrebuiltPlan, *Response, error) {
+func (p *PlansClient) listPrebuiltPlans(ctx context.Context, path, region string, opts *GetOptions, callOpts ...CallOption) ([]PrebuiltPlan, *Response, error) {
 	var pps []PrebuiltPlan
 
 	if opts == nil {
@@ -172,7 +183,7 @@ func (p *PlansClient) listPrebuiltPlans(ctx context.Context, path, region string
 	}
 	opts.QueryParams["region"] = region
 
-	req, err := p.client.NewRequest(ctx, http.MethodGet, opts.WithQuery(path), nil)
+	req, err := p.client.NewRequest(ctx, http.MethodGet, opts.WithQuery(path), nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -183,15 +194,31 @@ func (p *PlansClient) listPrebuiltPlans(ctx context.Context, path, region string
 
 // ListPrebuiltPlans retrieves variations of the base plan that have pre-assembled stock.
 // Mutates opts to set the region query parameter.
-func (p *PlansClient) ListPrebuiltPlans(ctx context.Context, basePlan, region string, opts *GetOptions) ([]PrebuiltPlan, *Response, error) {
+func (p *PlansClient) ListPrebuiltPlans(ctx context.Context, basePlan, region string, opts *GetOptions, callOpts ...CallOption) ([]PrebuiltPlan, *Response, error) {
 	path := fmt.Sprintf("%s/%s/prebuilts", basePlanPath, basePlan)
-	return p.listPrebuiltPlans(ctx, path, region, opts)
+	return p.listPrebuiltPlans(withOperation(ctx, "Plans.ListPrebuiltPlans"), path, region, opts, callOpts...)
+}
+
+// AllPrebuiltPlans iterates over all variations of the base plan that have pre-assembled stock,
+// fetching pages lazily.
+func (p *PlansClient) AllPrebuiltPlans(ctx context.Context, basePlan, region string, opts *GetOptions, callOpts ...CallOption) iter.Seq2[PrebuiltPlan, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]PrebuiltPlan, *Response, error) {
+		return p.ListPrebuiltPlans(ctx, basePlan, region, opts, callOpts...)
+	})
 }
 
 // ListPrebuiltTeamPlans retrieves variations of the base plan that have pre-assembled stock.
 // The pricing is adjusted according to your teams billing settings.
 // Mutates opts to set the region query parameter.
-func (p *PlansClient) ListPrebuiltTeamPlans(ctx context.Context, basePlan, region string, teamID int, opts *GetOptions) ([]PrebuiltPlan, *Response, error) {
+func (p *PlansClient) ListPrebuiltTeamPlans(ctx context.Context, basePlan, region string, teamID int, opts *GetOptions, callOpts ...CallOption) ([]PrebuiltPlan, *Response, error) {
 	path := fmt.Sprintf("%s/%d/plans/%s/prebuilts", teamPlanPath, teamID, basePlan)
-	return p.listPrebuiltPlans(ctx, path, region, opts)
+	return p.listPrebuiltPlans(withOperation(ctx, "Plans.ListPrebuiltTeamPlans"), path, region, opts, callOpts...)
+}
+
+// AllPrebuiltTeamPlans iterates over all variations of the base plan that have pre-assembled stock,
+// with team adjusted pricing, fetching pages lazily.
+func (p *PlansClient) AllPrebuiltTeamPlans(ctx context.Context, basePlan, region string, teamID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[PrebuiltPlan, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]PrebuiltPlan, *Response, error) {
+		return p.ListPrebuiltTeamPlans(ctx, basePlan, region, teamID, opts, callOpts...)
+	})
 }
@@ -187,9 +187,10 @@ func TestPlans_ListPrebuiltPlansPropagatesError(t *testing.T) {
 	defer teardown()
 
 	pps, resp, err := testClient.Plans.ListPrebuiltPlans(t.Context(), "test", "LT-Siauliai", nil)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 	assert.Nil(t, pps)
-	assert.Nil(t, resp)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 }
 
 func TestPlans_ListPrebuiltTeamPlansReturnsPlans(t *testing.T) {
@@ -238,6 +239,7 @@ func TestPlans_ListPrebuiltTeamPlansPropagatesError(t *testing.T) {
 
 	pps, resp, err := testClient.Plans.ListPrebuiltTeamPlans(t.Context(), "test", "LT-Siauliai", 123, nil)
 	assert.Nil(t, pps)
-	assert.Nil(t, resp)
-	assert.Error(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.ErrorIs(t, err, ErrNotFound)
 }
@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,12 +12,14 @@ const baseProjectPath = "/v1/projects"
 // ProjectsService is an interface for interfacing with the Projects endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Projects
 type ProjectsService interface {
-	List(ctx context.Context, teamID int, opts *GetOptions) ([]Project, *Response, error)
-	Get(ctx context.Context, projectID int, opts *GetOptions) (Project, *Response, error)
-	Create(ctx context.Context, teamID int, request *CreateProject) (Project, *Response, error)
-	Update(ctx context.Context, projectID int, request *UpdateProject) (Project, *Response, error)
-	ListSSHKeys(ctx context.Context, projectID int, opts *GetOptions) ([]SSHKey, *Response, error)
-	Delete(ctx context.Context, projectID int) (*Response, error)
+	List(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) ([]Project, *Response, error)
+	All(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Project, error]
+	Get(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) (Project, *Response, error)
+	Create(ctx context.Context, teamID int, request *CreateProject, callOpts ...CallOption) (Project, *Response, error)
+	Update(ctx context.Context, projectID int, request *UpdateProject, callOpts ...CallOption) (Project, *Response, error)
+	ListSSHKeys(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]SSHKey, *Response, error)
+	AllSSHKeys(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[SSHKey, error]
+	Delete(ctx context.Context, projectID int, callOpts ...CallOption) (*Response, error)
 }
 
 // Project data.
@@ -45,11 +48,11 @@ type ProjectsClient struct {
 }
 
 // List func lists projects
-func (p *ProjectsClient) List(ctx context.Context, teamID int, opts *GetOptions) ([]Project, *Response, error) {
-	path := opts.WithQuery(fmt.Sprintf("/v1/teams/%d/projects", teamID))
+func (p *ProjectsClient) List(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) ([]Project, *Response, error) {
+	path := opts.WithQuery(fmt.Sprintf("/v1/teams/%d/projects", p.client.teamID(teamID)))
 	var trans []Project
 
-	req, err := p.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := p.client.NewRequest(withOperation(ctx, "Projects.List"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -58,12 +61,19 @@ func (p *ProjectsClient) List(ctx context.Context, teamID int, opts *GetOptions)
 	return trans, resp, err
 }
 
+// All iterates over all team projects, fetching pages lazily.
+func (p *ProjectsClient) All(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Project, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]Project, *Response, error) {
+		return p.List(ctx, teamID, opts, callOpts...)
+	})
+}
+
 // Get project.
-func (p *ProjectsClient) Get(ctx context.Context, projectID int, opts *GetOptions) (Project, *Response, error) {
+func (p *ProjectsClient) Get(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) (Project, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", baseProjectPath, projectID))
 	var trans Project
 
-	req, err := p.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := p.client.NewRequest(withOperation(ctx, "Projects.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return Project{}, nil, err
 	}
@@ -73,11 +83,11 @@ func (p *ProjectsClient) Get(ctx context.Context, projectID int, opts *GetOption
 }
 
 // Create func will create new Project for specified team
-func (p *ProjectsClient) Create(ctx context.Context, teamID int, request *CreateProject) (Project, *Response, error) {
+func (p *ProjectsClient) Create(ctx context.Context, teamID int, request *CreateProject, callOpts ...CallOption) (Project, *Response, error) {
 	var trans Project
-	path := fmt.Sprintf("/v1/teams/%d/projects", teamID)
+	path := fmt.Sprintf("/v1/teams/%d/projects", p.client.teamID(teamID))
 
-	req, err := p.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := p.client.NewRequest(withOperation(ctx, "Projects.Create"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return Project{}, nil, err
 	}
@@ -87,11 +97,11 @@ func (p *ProjectsClient) Create(ctx context.Context, teamID int, request *Create
 }
 
 // Update func will update a project
-func (p *ProjectsClient) Update(ctx context.Context, projectID int, request *UpdateProject) (Project, *Response, error) {
+func (p *ProjectsClient) Update(ctx context.Context, projectID int, request *UpdateProject, callOpts ...CallOption) (Project, *Response, error) {
 	var trans Project
 	path := fmt.Sprintf("%s/%d", baseProjectPath, projectID)
 
-	req, err := p.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := p.client.NewRequest(withOperation(ctx, "Projects.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return Project{}, nil, err
 	}
@@ -101,10 +111,10 @@ func (p *ProjectsClient) Update(ctx context.Context, projectID int, request *Upd
 }
 
 // Delete func will delete a project
-func (p *ProjectsClient) Delete(ctx context.Context, projectID int) (*Response, error) {
+func (p *ProjectsClient) Delete(ctx context.Context, projectID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d", baseProjectPath, projectID)
 
-	req, err := p.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := p.client.NewRequest(withOperation(ctx, "Projects.Delete"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,11 +124,11 @@ func (p *ProjectsClient) Delete(ctx context.Context, projectID int) (*Response,
 }
 
 // ListSSHKeys available for project.
-func (p *ProjectsClient) ListSSHKeys(ctx context.Context, projectID int, opts *GetOptions) ([]SSHKey, *Response, error) {
+func (p *ProjectsClient) ListSSHKeys(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]SSHKey, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("/v1/projects/%d/ssh-keys", projectID))
 	var trans []SSHKey
 
-	req, err := p.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := p.client.NewRequest(withOperation(ctx, "Projects.ListSSHKeys"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -126,3 +136,10 @@ func (p *ProjectsClient) ListSSHKeys(ctx context.Context, projectID int, opts *G
 	resp, err := p.client.Do(req, &trans)
 	return trans, resp, err
 }
+
+// AllSSHKeys iterates over all SSH keys available for project, fetching pages lazily.
+func (p *ProjectsClient) AllSSHKeys(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[SSHKey, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]SSHKey, *Response, error) {
+		return p.ListSSHKeys(ctx, projectID, opts, callOpts...)
+	})
+}
@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,8 +12,9 @@ const baseRegionPath = "/v1/regions"
 // RegionsService is an interface for interfacing with the the Images endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Regions
 type RegionsService interface {
-	List(ctx context.Context, opts *GetOptions) ([]Region, *Response, error)
-	Get(ctx context.Context, region string, opts *GetOptions) (Region, *Response, error)
+	List(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]Region, *Response, error)
+	All(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Region, error]
+	Get(ctx context.Context, region string, opts *GetOptions, callOpts ...CallOption) (Region, *Response, error)
 }
 
 // Region fields
@@ -32,11 +34,11 @@ type RegionsClient struct {
 }
 
 // List all regions.
-func (i *RegionsClient) List(ctx context.Context, opts *GetOptions) ([]Region, *Response, error) {
+func (i *RegionsClient) List(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]Region, *Response, error) {
 	path := opts.WithQuery(baseRegionPath)
 	var trans []Region
 
-	req, err := i.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := i.client.NewRequest(withOperation(ctx, "Regions.List"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -45,12 +47,19 @@ func (i *RegionsClient) List(ctx context.Context, opts *GetOptions) ([]Region, *
 	return trans, resp, err
 }
 
+// All iterates over all regions, fetching pages lazily.
+func (i *RegionsClient) All(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Region, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]Region, *Response, error) {
+		return i.List(ctx, opts, callOpts...)
+	})
+}
+
 // Get region.
-func (i *RegionsClient) Get(ctx context.Context, region string, opts *GetOptions) (Region, *Response, error) {
+func (i *RegionsClient) Get(ctx context.Context, region string, opts *GetOptions, callOpts ...CallOption) (Region, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%s", baseRegionPath, region))
 	var trans Region
 
-	req, err := i.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := i.client.NewRequest(withOperation(ctx, "Regions.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return Region{}, nil, err
 	}
@@ -0,0 +1,133 @@
+package cherrygo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v4/backoff"
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+)
+
+// RetryPolicy overrides the client retry policy for requests
+// made with a context from [ContextWithRetryPolicy].
+type RetryPolicy struct {
+	// MaxRetries is the maximum amount of retries, after the first attempt.
+	// Zero disables retries. The client setting is used if nil, see [WithMaxRetries].
+	MaxRetries *int
+
+	// Backoff generates the delays between attempts.
+	// The client backoff is used if nil.
+	Backoff backoff.Func
+
+	// RetryableStatuses are the response status codes that are retried.
+	// The client statuses are used if nil.
+	RetryableStatuses []int
+
+	// RetryConnectionErrors sets whether connection resets and unexpected EOFs
+	// count as transient errors, alongside timeouts.
+	// The client setting is used if nil, see [WithRetryConnectionErrors].
+	RetryConnectionErrors *bool
+}
+
+// ContextWithRetryPolicy returns a copy of ctx that makes requests created
+// with it follow policy instead of the client retry policy.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return client.ContextWithPolicy(ctx, func(p *client.Policy) {
+		if policy.MaxRetries != nil {
+			p.MaxRetries = *policy.MaxRetries
+		}
+		if policy.RetryConnectionErrors != nil {
+			p.RetryConnectionErrors = *policy.RetryConnectionErrors
+		}
+		if policy.Backoff != nil {
+			p.Backoff = policy.Backoff
+		}
+		if policy.RetryableStatuses != nil {
+			p.RetryableStatuses = policy.RetryableStatuses
+		}
+	})
+}
+
+// ContextWithoutRetries returns a copy of ctx that makes requests
+// created with it be attempted only once, e.g. for health checks.
+func ContextWithoutRetries(ctx context.Context) context.Context {
+	return client.ContextWithPolicy(ctx, func(p *client.Policy) {
+		p.MaxRetries = 0
+	})
+}
+
+// WithMaxRetries sets the maximum amount of retries for failed requests.
+// Defaults to 5, zero disables retries.
+func WithMaxRetries(n int) ClientOpt {
+	return func(c *options) error {
+		if n < 0 {
+			return fmt.Errorf("max retries must not be negative, got %d", n)
+		}
+		c.clientOpts = append(c.clientOpts, client.WithMaxRetries(n))
+		return nil
+	}
+}
+
+// WithRetryBackoff sets the backoff function for delays between request attempts.
+// Defaults to [backoff.RateLimitedExponentialBackoff], with a base of 1s,
+// capped at 30s. Requests stop being retried early when b returns [backoff.Stop].
+func WithRetryBackoff(b backoff.Func) ClientOpt {
+	return func(c *options) error {
+		if b == nil {
+			return errors.New("retry backoff must not be nil")
+		}
+		c.clientOpts = append(c.clientOpts, client.WithBackoff(b))
+		return nil
+	}
+}
+
+// WithMaxRetryAfter limits the delays that `Retry-After` headers ask for to d.
+// Defaults to [backoff.DefaultMaxRetryAfter]. Custom backoff functions may limit
+// them further, e.g. [backoff.RateLimitedExponentialBackoff]. It also limits
+// how long [WithRateLimit] pauses requests when the API reports its limit exhausted.
+func WithMaxRetryAfter(d time.Duration) ClientOpt {
+	return func(c *options) error {
+		if d <= 0 {
+			return fmt.Errorf("max retry after must be positive, got %v", d)
+		}
+		c.clientOpts = append(c.clientOpts, client.WithMaxRetryAfter(d))
+		return nil
+	}
+}
+
+// WithRetryableStatuses sets the response status codes that are retried.
+// Defaults to 408, 429, 502, 503 and 504.
+// Requests that are not idempotent are never retried.
+func WithRetryableStatuses(statuses ...int) ClientOpt {
+	return func(c *options) error {
+		c.clientOpts = append(c.clientOpts, client.WithRetryableStatuses(statuses))
+		return nil
+	}
+}
+
+// WithRetryConnectionErrors sets whether connection resets and unexpected EOFs
+// are retried, like timeouts are. Disabled by default.
+func WithRetryConnectionErrors(enabled bool) ClientOpt {
+	return func(c *options) error {
+		c.clientOpts = append(c.clientOpts, client.WithRetryConnectionErrors(enabled))
+		return nil
+	}
+}
+
+// retryAbortError is returned when the client gives up retrying a request
+// that got a response. It matches both the [*APIError] of the last response
+// and the reason, e.g. [ErrRetryDeadline].
+type retryAbortError struct {
+	retryErr *client.RetryError
+	apiErr   *APIError
+}
+
+func (e *retryAbortError) Error() string {
+	return e.retryErr.Abort() + ": " + e.apiErr.Error()
+}
+
+func (e *retryAbortError) Unwrap() []error {
+	return []error{e.apiErr, e.retryErr}
+}
@@ -0,0 +1,217 @@
+package cherrygo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryBackoff(_ int, _ *http.Response) time.Duration {
+	return time.Millisecond
+}
+
+func TestRetryOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithMaxRetries(2),
+		WithRetryBackoff(testRetryBackoff),
+		WithRetryableStatuses(http.StatusInternalServerError),
+	)
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, _, err = c.Servers.Get(t.Context(), 1, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryResponseMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithRetryBackoff(testRetryBackoff))
+	require.NoError(t, err)
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+
+	_, resp, err := c.Servers.Get(t.Context(), 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.Attempts)
+	assert.Equal(t, 2*time.Millisecond, resp.Backoff)
+}
+
+func TestContextWithoutRetries(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, resp, err := testClient.Servers.Get(ContextWithoutRetries(t.Context()), 1, nil)
+	assert.ErrorIs(t, err, ErrServerError)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, resp.Attempts)
+}
+
+func TestContextWithRetryPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	maxRetries := 1
+	ctx := ContextWithRetryPolicy(t.Context(), RetryPolicy{
+		MaxRetries:        &maxRetries,
+		Backoff:           testRetryBackoff,
+		RetryableStatuses: []int{http.StatusTeapot},
+	})
+
+	_, _, err := testClient.Servers.Get(ctx, 1, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestContextWithRetryPolicyClientMaxRetries(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(WithAPIKey("key"), WithURL(ts.URL), WithMaxRetries(2))
+	require.NoError(t, err)
+
+	ctx := ContextWithRetryPolicy(t.Context(), RetryPolicy{
+		Backoff:           testRetryBackoff,
+		RetryableStatuses: []int{http.StatusTeapot},
+	})
+
+	_, _, err = c.Servers.Get(ctx, 1, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+// resetTransport fails every request with a connection reset.
+type resetTransport struct {
+	calls int
+}
+
+func (rt *resetTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	rt.calls++
+	return nil, syscall.ECONNRESET
+}
+
+func TestContextWithRetryPolicyConnectionErrors(t *testing.T) {
+	disabled := false
+	maxRetries := 2
+	cases := []struct {
+		title     string
+		policy    RetryPolicy
+		wantCalls int
+	}{
+		{
+			title:     "client setting",
+			policy:    RetryPolicy{MaxRetries: &maxRetries, Backoff: testRetryBackoff},
+			wantCalls: 3,
+		},
+		{
+			title:     "overridden",
+			policy:    RetryPolicy{MaxRetries: &maxRetries, Backoff: testRetryBackoff, RetryConnectionErrors: &disabled},
+			wantCalls: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			rt := &resetTransport{}
+			c, err := NewClient(
+				WithAPIKey("key"),
+				WithHTTPClient(&http.Client{Transport: rt}),
+				WithRetryConnectionErrors(true),
+			)
+			require.NoError(t, err)
+
+			_, _, err = c.Servers.Get(ContextWithRetryPolicy(t.Context(), tc.policy), 1, nil)
+			assert.ErrorIs(t, err, syscall.ECONNRESET)
+			assert.Equal(t, tc.wantCalls, rt.calls)
+		})
+	}
+}
+
+func TestRetryOptionsValidation(t *testing.T) {
+	_, err := NewClient(WithAPIKey("key"), WithMaxRetries(-1))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithAPIKey("key"), WithRetryBackoff(nil))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithAPIKey("key"), WithMaxRetryAfter(0))
+	assert.Error(t, err)
+}
+
+func TestRetryAbortReasons(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/v1/servers/1", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	start := time.Now()
+	_, resp, err := testClient.Servers.Get(t.Context(), 1, nil, Timeout(5*time.Second))
+	assert.Less(t, time.Since(start), time.Second, "waited for a retry past the deadline")
+
+	require.NotNil(t, resp)
+	assert.Equal(t, 1, resp.Attempts)
+	assert.ErrorIs(t, err, ErrRetryDeadline)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.NotErrorIs(t, err, ErrRetryAttemptLimit)
+	assert.ErrorContains(t, err, "retry in 1m0s would pass the context deadline, after 1 attempts: error response from API")
+
+	c, err := NewClient(WithURL(server.URL), WithMaxRetries(1), WithMaxRetryAfter(time.Millisecond))
+	require.NoError(t, err)
+
+	_, resp, err = c.Servers.Get(t.Context(), 1, nil)
+	require.NotNil(t, resp)
+	assert.Equal(t, 2, resp.Attempts)
+	assert.ErrorIs(t, err, ErrRetryAttemptLimit)
+	assert.ErrorContains(t, err, "max retries 1 exceeded: error response from API")
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+}
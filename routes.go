@@ -0,0 +1,170 @@
+package cherrygo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// route is an API endpoint used by the client.
+type route struct {
+	method string
+
+	// pattern is the templated endpoint path, without the API version prefix.
+	// Wildcard segments are enclosed in braces.
+	pattern string
+
+	// operation is the name of the service method that calls the endpoint,
+	// or of the endpoint if several do, see sharedRoutes.
+	operation string
+}
+
+var routes = []route{
+	{http.MethodGet, "/teams", "Teams.List"},
+	{http.MethodPost, "/teams", "Teams.Create"},
+	{http.MethodGet, "/teams/{team_id}", "Teams.Get"},
+	{http.MethodPut, "/teams/{team_id}", "Teams.Update"},
+	{http.MethodDelete, "/teams/{team_id}", "Teams.Delete"},
+
+	{http.MethodGet, "/teams/{team_id}/projects", "Projects.List"},
+	{http.MethodPost, "/teams/{team_id}/projects", "Projects.Create"},
+	{http.MethodGet, "/projects/{project_id}", "Projects.Get"},
+	{http.MethodPut, "/projects/{project_id}", "Projects.Update"},
+	{http.MethodDelete, "/projects/{project_id}", "Projects.Delete"},
+	{http.MethodGet, "/projects/{project_id}/ssh-keys", "Projects.ListSSHKeys"},
+
+	{http.MethodGet, "/plans", "Plans.List"},
+	{http.MethodGet, "/teams/{team_id}/plans", "Plans.List"},
+	{http.MethodGet, "/plans/{plan}", "Plans.Get"},
+	{http.MethodGet, "/plans/{plan}/prebuilts", "Plans.ListPrebuiltPlans"},
+	{http.MethodGet, "/teams/{team_id}/plans/{plan}/prebuilts", "Plans.ListPrebuiltTeamPlans"},
+	{http.MethodGet, "/plans/{plan}/images", "Images.List"},
+
+	{http.MethodGet, "/ssh-keys", "SSHKeys.List"},
+	{http.MethodPost, "/ssh-keys", "SSHKeys.Create"},
+	{http.MethodGet, "/ssh-keys/{ssh_key_id}", "SSHKeys.Get"},
+	{http.MethodPut, "/ssh-keys/{ssh_key_id}", "SSHKeys.Update"},
+	{http.MethodDelete, "/ssh-keys/{ssh_key_id}", "SSHKeys.Delete"},
+
+	{http.MethodGet, "/projects/{project_id}/servers", "Servers.List"},
+	{http.MethodPost, "/projects/{project_id}/servers", "Servers.Create"},
+	{http.MethodGet, "/servers/{server_id}", "Servers.Get"},
+	{http.MethodPut, "/servers/{server_id}", "Servers.Update"},
+	{http.MethodDelete, "/servers/{server_id}", "Servers.Delete"},
+	{http.MethodPost, "/servers/{server_id}/actions", "Servers.Action"},
+	{http.MethodGet, "/servers/{server_id}/ssh-keys", "Servers.ListSSHKeys"},
+	{http.MethodGet, "/cycles", "Servers.ListCycles"},
+
+	{http.MethodGet, "/projects/{project_id}/ips", "IPAddresses.List"},
+	{http.MethodPost, "/projects/{project_id}/ips", "IPAddresses.Create"},
+	{http.MethodGet, "/ips/{ip_id}", "IPAddresses.Get"},
+	{http.MethodPut, "/ips/{ip_id}", "IPAddresses.Update"},
+	{http.MethodDelete, "/ips/{ip_id}", "IPAddresses.Remove"},
+
+	{http.MethodGet, "/projects/{project_id}/storages", "Storages.List"},
+	{http.MethodPost, "/projects/{project_id}/storages", "Storages.Create"},
+	{http.MethodGet, "/storages/{storage_id}", "Storages.Get"},
+	{http.MethodPut, "/storages/{storage_id}", "Storages.Update"},
+	{http.MethodDelete, "/storages/{storage_id}", "Storages.Delete"},
+	{http.MethodPost, "/storages/{storage_id}/attachments", "Storages.Attach"},
+	{http.MethodDelete, "/storages/{storage_id}/attachments", "Storages.Detach"},
+
+	{http.MethodGet, "/regions", "Regions.List"},
+	{http.MethodGet, "/regions/{region}", "Regions.Get"},
+
+	{http.MethodGet, "/user", "Users.CurrentUser"},
+	{http.MethodGet, "/users/{user_id}", "Users.Get"},
+
+	{http.MethodGet, "/backup-storage-plans", "Backups.ListPlans"},
+	{http.MethodGet, "/projects/{project_id}/backup-storages", "Backups.ListBackups"},
+	{http.MethodPost, "/servers/{server_id}/backup-storages", "Backups.Create"},
+	{http.MethodGet, "/backup-storages/{backup_id}", "Backups.Get"},
+	{http.MethodPut, "/backup-storages/{backup_id}", "Backups.Update"},
+	{http.MethodDelete, "/backup-storages/{backup_id}", "Backups.Delete"},
+	{http.MethodPatch, "/backup-storages/{backup_id}/methods/{method}", "Backups.UpdateBackupMethod"},
+}
+
+// sharedRoutes lists the operations that call the route of another operation,
+// by the operation of the route.
+var sharedRoutes = map[string][]string{
+	"Plans.Get":          {"Plans.GetByID", "Plans.GetBySlug"},
+	"Servers.Get":        {"Servers.PowerState"},
+	"Servers.Action":     {"Servers.PowerOff", "Servers.PowerOn", "Servers.Reboot", "Servers.EnterRescueMode", "Servers.ExitRescueMode", "Servers.ResetBMCPassword", "Servers.Reinstall", "Servers.Upgrade", "Servers.AllowBMCAccess"},
+	"IPAddresses.Update": {"IPAddresses.Assign", "IPAddresses.Unassign"},
+}
+
+type operationCtxKey struct{}
+
+// withOperation returns a copy of ctx that names the operation of requests
+// created with it. Service methods name their requests, since several of them
+// may call the same route, e.g. every server action.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationCtxKey{}, operation)
+}
+
+// requestRoute finds the route of req, named after the operation set with
+// [withOperation], or else after the route, e.g. for requests of [Client.NewRequest].
+func requestRoute(req *http.Request) matchedRoute {
+	route := matchRoute(req.Method, req.URL.Path)
+	if op, ok := req.Context().Value(operationCtxKey{}).(string); ok {
+		route.operation = op
+	}
+	return route
+}
+
+// matchedRoute is a route matched against a request path.
+type matchedRoute struct {
+	route
+
+	// params are the values of the pattern wildcards, by name.
+	params map[string]string
+
+	// matched is false if no route matched, so the pattern is the raw path.
+	matched bool
+}
+
+// matchRoute finds the route of a request. If none match, the returned route
+// has the raw path as its pattern and the method as its operation.
+func matchRoute(method, path string) matchedRoute {
+	path = strings.TrimPrefix(path, "/v1")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, r := range routes {
+		if r.method != method {
+			continue
+		}
+		if params, ok := matchPattern(r.pattern, segments); ok {
+			return matchedRoute{route: r, params: params, matched: true}
+		}
+	}
+
+	return matchedRoute{route: route{method: method, pattern: path, operation: method}}
+}
+
+func matchPattern(pattern string, segments []string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	if len(patternSegments) != len(segments) {
+		return nil, false
+	}
+
+	var params map[string]string
+	for i, ps := range patternSegments {
+		if name, ok := strings.CutPrefix(ps, "{"); ok {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[strings.TrimSuffix(name, "}")] = segments[i]
+			continue
+		}
+		if ps != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// templatedPath returns the route pattern with the API version prefix,
+// e.g. /v1/servers/{server_id}.
+func (r matchedRoute) templatedPath() string {
+	return "/v1" + r.pattern
+}
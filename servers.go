@@ -2,13 +2,14 @@ package cherrygo
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
+	"iter"
 	"net/http"
 	"net/netip"
 	"time"
+
+	"github.com/cherryservers/cherrygo/v4/backoff"
 )
 
 const (
@@ -57,24 +58,27 @@ func (ss ServerStatus) String() string {
 // ServersService is an interface for interfacing with the Server endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Servers
 type ServersService interface {
-	List(ctx context.Context, projectID int, opts *GetOptions) ([]Server, *Response, error)
-	Get(ctx context.Context, serverID int, opts *GetOptions) (Server, *Response, error)
-	PowerOff(ctx context.Context, serverID int) (Server, *Response, error)
-	PowerOn(ctx context.Context, serverID int) (Server, *Response, error)
-	Create(ctx context.Context, request *CreateServer) (Server, *Response, error)
-	Delete(ctx context.Context, serverID int) (*Response, error)
-	PowerState(ctx context.Context, serverID int) (PowerState, *Response, error)
-	Reboot(ctx context.Context, serverID int) (Server, *Response, error)
-	EnterRescueMode(ctx context.Context, serverID int, fields *RescueServerFields) (Server, *Response, error)
-	ExitRescueMode(ctx context.Context, serverID int) (Server, *Response, error)
-	Update(ctx context.Context, serverID int, request *UpdateServer) (Server, *Response, error)
-	Reinstall(ctx context.Context, serverID int, fields *ReinstallServerFields) (Server, *Response, error)
-	ListSSHKeys(ctx context.Context, serverID int, opts *GetOptions) ([]SSHKey, *Response, error)
-	ResetBMCPassword(ctx context.Context, serverID int) (Server, *Response, error)
-	ListCycles(ctx context.Context, opts *GetOptions) ([]ServerCycle, *Response, error)
-	Upgrade(ctx context.Context, serverID int, plan string) (Server, *Response, error)
-	AllowBMCAccess(ctx context.Context, serverID int, ip4 string) (Server, *Response, error)
-	WaitForStatus(ctx context.Context, serverID int, status ServerStatus) (Server, *Response, error)
+	List(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]Server, *Response, error)
+	All(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Server, error]
+	Get(ctx context.Context, serverID int, opts *GetOptions, callOpts ...CallOption) (Server, *Response, error)
+	PowerOff(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error)
+	PowerOn(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error)
+	Create(ctx context.Context, request *CreateServer, callOpts ...CallOption) (Server, *Response, error)
+	Delete(ctx context.Context, serverID int, callOpts ...CallOption) (*Response, error)
+	PowerState(ctx context.Context, serverID int, callOpts ...CallOption) (PowerState, *Response, error)
+	Reboot(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error)
+	EnterRescueMode(ctx context.Context, serverID int, fields *RescueServerFields, callOpts ...CallOption) (Server, *Response, error)
+	ExitRescueMode(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error)
+	Update(ctx context.Context, serverID int, request *UpdateServer, callOpts ...CallOption) (Server, *Response, error)
+	Reinstall(ctx context.Context, serverID int, fields *ReinstallServerFields, callOpts ...CallOption) (Server, *Response, error)
+	ListSSHKeys(ctx context.Context, serverID int, opts *GetOptions, callOpts ...CallOption) ([]SSHKey, *Response, error)
+	AllSSHKeys(ctx context.Context, serverID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[SSHKey, error]
+	ResetBMCPassword(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error)
+	ListCycles(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]ServerCycle, *Response, error)
+	AllCycles(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[ServerCycle, error]
+	Upgrade(ctx context.Context, serverID int, plan string, callOpts ...CallOption) (Server, *Response, error)
+	AllowBMCAccess(ctx context.Context, serverID int, ip4 string, callOpts ...CallOption) (Server, *Response, error)
+	WaitForStatus(ctx context.Context, serverID int, status ServerStatus, callOpts ...CallOption) (Server, *Response, error)
 }
 
 // Server response object
@@ -234,26 +238,51 @@ type ServersClient struct {
 	client *Client
 }
 
-// List func lists teams
-func (s *ServersClient) List(ctx context.Context, projectID int, opts *GetOptions) ([]Server, *Response, error) {
-	path := opts.WithQuery(fmt.Sprintf("/v1/projects/%d/servers", projectID))
+// List lists project servers. See [ServerFilter] for filtering them.
+func (s *ServersClient) List(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]Server, *Response, error) {
+	filter, callOpts, err := listFilter[Server](callOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trans, resp, err := s.list(withOperation(ctx, "Servers.List"), projectID, opts, filter, callOpts...)
+	return filterItems(trans, filter), resp, err
+}
+
+// All iterates over all project servers, fetching pages lazily.
+func (s *ServersClient) All(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Server, error] {
+	filter, callOpts, err := listFilter[Server](callOpts)
+	if err != nil {
+		return errSeq[Server](err)
+	}
+
+	// Filter the iterator rather than the pages,
+	// so short filtered pages don't end pagination.
+	return filterSeq(Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]Server, *Response, error) {
+		return s.list(withOperation(ctx, "Servers.List"), projectID, opts, filter, callOpts...)
+	}), filter)
+}
+
+func (s *ServersClient) list(ctx context.Context, projectID int, opts *GetOptions, filter ListFilter, callOpts ...CallOption) ([]Server, *Response, error) {
+	path := opts.WithQuery(fmt.Sprintf("/v1/projects/%d/servers", s.client.projectID(projectID)))
 	var trans []Server
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
+	applyFilter(req, filter)
 
 	resp, err := s.client.Do(req, &trans)
 	return trans, resp, err
 }
 
 // Get server.
-func (s *ServersClient) Get(ctx context.Context, serverID int, opts *GetOptions) (Server, *Response, error) {
+func (s *ServersClient) Get(ctx context.Context, serverID int, opts *GetOptions, callOpts ...CallOption) (Server, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", baseServerPath, serverID))
 	var trans Server
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -262,11 +291,11 @@ func (s *ServersClient) Get(ctx context.Context, serverID int, opts *GetOptions)
 	return trans, resp, err
 }
 
-func (s *ServersClient) action(ctx context.Context, serverID int, serverAction ServerAction) (Server, *Response, error) {
+func (s *ServersClient) action(ctx context.Context, serverID int, serverAction ServerAction, callOpts ...CallOption) (Server, *Response, error) {
 	var trans Server
 	path := fmt.Sprintf("%s/%d/actions", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, serverAction)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, serverAction, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -276,39 +305,45 @@ func (s *ServersClient) action(ctx context.Context, serverID int, serverAction S
 }
 
 // PowerOff function turns server off
-func (s *ServersClient) PowerOff(ctx context.Context, serverID int) (Server, *Response, error) {
+func (s *ServersClient) PowerOff(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error) {
 	action := ServerAction{
 		Type: "power_off",
 	}
 
-	return s.action(ctx, serverID, action)
+	return s.action(withOperation(ctx, "Servers.PowerOff"), serverID, action, callOpts...)
 }
 
 // PowerOn function turns server on
-func (s *ServersClient) PowerOn(ctx context.Context, serverID int) (Server, *Response, error) {
+func (s *ServersClient) PowerOn(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error) {
 	action := ServerAction{
 		Type: "power_on",
 	}
 
-	return s.action(ctx, serverID, action)
+	return s.action(withOperation(ctx, "Servers.PowerOn"), serverID, action, callOpts...)
 }
 
 // Reboot function restarts desired server
-func (s *ServersClient) Reboot(ctx context.Context, serverID int) (Server, *Response, error) {
+func (s *ServersClient) Reboot(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error) {
 	action := ServerAction{
 		Type: "reboot",
 	}
 
-	return s.action(ctx, serverID, action)
+	return s.action(withOperation(ctx, "Servers.Reboot"), serverID, action, callOpts...)
 }
 
 // EnterRescueMode on server.
-func (s *ServersClient) EnterRescueMode(ctx context.Context, serverID int, fields *RescueServerFields) (Server, *Response, error) {
+func (s *ServersClient) EnterRescueMode(ctx context.Context, serverID int, fields *RescueServerFields, callOpts ...CallOption) (Server, *Response, error) {
+	if fields != nil && s.client.shouldValidate(callOpts) {
+		if err := fields.Validate(); err != nil {
+			return Server{}, nil, err
+		}
+	}
+
 	var trans Server
 	request := &rescueServer{ServerAction{Type: "enter-rescue-mode"}, fields}
 	path := fmt.Sprintf("%s/%d/actions", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.EnterRescueMode"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -318,30 +353,34 @@ func (s *ServersClient) EnterRescueMode(ctx context.Context, serverID int, field
 }
 
 // ExitRescueMode on server.
-func (s *ServersClient) ExitRescueMode(ctx context.Context, serverID int) (Server, *Response, error) {
+func (s *ServersClient) ExitRescueMode(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error) {
 	action := ServerAction{
 		Type: "exit-rescue-mode",
 	}
 
-	return s.action(ctx, serverID, action)
+	return s.action(withOperation(ctx, "Servers.ExitRescueMode"), serverID, action, callOpts...)
 }
 
 // ResetBMCPassword for bare metal server.
-func (s *ServersClient) ResetBMCPassword(ctx context.Context, serverID int) (Server, *Response, error) {
+func (s *ServersClient) ResetBMCPassword(ctx context.Context, serverID int, callOpts ...CallOption) (Server, *Response, error) {
 	action := ServerAction{
 		Type: "reset-bmc-password",
 	}
 
-	return s.action(ctx, serverID, action)
+	return s.action(withOperation(ctx, "Servers.ResetBMCPassword"), serverID, action, callOpts...)
 }
 
 // Reinstall server OS.
-func (s *ServersClient) Reinstall(ctx context.Context, serverID int, fields *ReinstallServerFields) (Server, *Response, error) {
+func (s *ServersClient) Reinstall(ctx context.Context, serverID int, fields *ReinstallServerFields, callOpts ...CallOption) (Server, *Response, error) {
+	if err := s.validateReinstall(ctx, serverID, fields, callOpts); err != nil {
+		return Server{}, nil, err
+	}
+
 	var trans Server
 	request := &reinstallRequest{ServerAction{Type: "reinstall"}, fields}
 	path := fmt.Sprintf("%s/%d/actions", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.Reinstall"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -350,8 +389,24 @@ func (s *ServersClient) Reinstall(ctx context.Context, serverID int, fields *Rei
 	return trans, resp, err
 }
 
+// validateReinstall validates fields if the client or call options ask for it.
+func (s *ServersClient) validateReinstall(ctx context.Context, serverID int, fields *ReinstallServerFields, callOpts []CallOption) error {
+	if fields == nil || !s.client.shouldValidate(callOpts) {
+		return nil
+	}
+	if err := fields.Validate(); err != nil || fields.OSPartitionSize == 0 {
+		return err
+	}
+
+	srv, _, err := s.Get(ctx, serverID, &GetOptions{Fields: []string{"plan"}})
+	if err != nil {
+		return fmt.Errorf("failed to get server %d to validate the reinstall: %w", serverID, err)
+	}
+	return validatePartitionSize("ReinstallServerFields", srv.Plan, fields.OSPartitionSize)
+}
+
 // Upgrade virtual server plan.
-func (s *ServersClient) Upgrade(ctx context.Context, serverID int, plan string) (Server, *Response, error) {
+func (s *ServersClient) Upgrade(ctx context.Context, serverID int, plan string, callOpts ...CallOption) (Server, *Response, error) {
 	var trans Server
 	request := &UpgradeServer{
 		ServerAction: ServerAction{Type: "upgrade"},
@@ -359,7 +414,7 @@ func (s *ServersClient) Upgrade(ctx context.Context, serverID int, plan string)
 	}
 	path := fmt.Sprintf("%s/%d/actions", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.Upgrade"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -370,7 +425,7 @@ func (s *ServersClient) Upgrade(ctx context.Context, serverID int, plan string)
 
 // AllowBMCAccess allows BMC/IPMI access from the specified IPv4 address for a limited duration.
 // If ip4 is empty, no whitelist will be used, i.e. all addresses will be allowed.
-func (s *ServersClient) AllowBMCAccess(ctx context.Context, serverID int, ip4 string) (Server, *Response, error) {
+func (s *ServersClient) AllowBMCAccess(ctx context.Context, serverID int, ip4 string, callOpts ...CallOption) (Server, *Response, error) {
 	var srv Server
 	body := &allowBMCAccess{
 		ServerAction: ServerAction{Type: "create-console-access"},
@@ -378,7 +433,7 @@ func (s *ServersClient) AllowBMCAccess(ctx context.Context, serverID int, ip4 st
 	}
 	path := fmt.Sprintf("%s/%d/actions", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, body)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.AllowBMCAccess"), http.MethodPost, path, body, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -388,11 +443,11 @@ func (s *ServersClient) AllowBMCAccess(ctx context.Context, serverID int, ip4 st
 }
 
 // PowerState retrieves server power state.
-func (s *ServersClient) PowerState(ctx context.Context, serverID int) (PowerState, *Response, error) {
+func (s *ServersClient) PowerState(ctx context.Context, serverID int, callOpts ...CallOption) (PowerState, *Response, error) {
 	path := fmt.Sprintf("%s/%d?fields=power", baseServerPath, serverID)
 	var trans PowerState
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.PowerState"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return PowerState{}, nil, err
 	}
@@ -402,11 +457,110 @@ func (s *ServersClient) PowerState(ctx context.Context, serverID int) (PowerStat
 }
 
 // Create server.
-func (s *ServersClient) Create(ctx context.Context, request *CreateServer) (Server, *Response, error) {
+//
+// If the client has create reconciliation enabled and the order fails
+// ambiguously, the project servers are searched for the ordered one before
+// ordering it again, see [WithCreateReconciliation]. A server found this way
+// is returned with the response of the server list it was found in.
+func (s *ServersClient) Create(ctx context.Context, request *CreateServer, callOpts ...CallOption) (Server, *Response, error) {
+	request = s.client.Defaults.applyToServer(request)
+	if err := s.validateCreate(ctx, request, callOpts); err != nil {
+		return Server{}, nil, err
+	}
+
+	// Both attempts of a reconciled order carry the same key,
+	// so the API can tell the second one is a repeat.
+	if s.client.idempotencyKeys {
+		var err error
+		if ctx, err = contextWithIdempotencyKey(ctx, callOpts); err != nil {
+			return Server{}, nil, err
+		}
+	}
+
+	started := time.Now()
+	srv, resp, err := s.create(withOperation(ctx, "Servers.Create"), request, callOpts...)
+	if s.client.reconcileWindow == 0 || !isAmbiguous(err) || !reconcilable(request) {
+		return srv, resp, err
+	}
+
+	found, listResp, rErr := s.findCreated(ctx, request, started.Add(-s.client.reconcileWindow))
+	if rErr != nil {
+		return Server{}, resp, fmt.Errorf("failed to reconcile server order: %w, after: %w", rErr, err)
+	}
+	if found != nil {
+		return *found, listResp, nil
+	}
+
+	return s.create(withOperation(ctx, "Servers.Create"), request, callOpts...)
+}
+
+// validateCreate validates request if the client or call options ask for it.
+func (s *ServersClient) validateCreate(ctx context.Context, request *CreateServer, callOpts []CallOption) error {
+	if request == nil || !s.client.shouldValidate(callOpts) {
+		return nil
+	}
+	if err := request.Validate(); err != nil || request.OSPartitionSize == 0 {
+		return err
+	}
+
+	plan, _, err := s.client.Plans.GetBySlug(ctx, request.Plan, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get plan %q to validate the order: %w", request.Plan, err)
+	}
+	return validatePartitionSize("CreateServer", plan, request.OSPartitionSize)
+}
+
+func reconcilable(request *CreateServer) bool {
+	return request.Hostname != "" || (request.Tags != nil && len(*request.Tags) > 0)
+}
+
+// findCreated looks for a project server that matches the order request
+// and was created after since, returning it with the response of its page,
+// or nil if there is none.
+func (s *ServersClient) findCreated(ctx context.Context, request *CreateServer, since time.Time) (*Server, *Response, error) {
+	var resp *Response
+	pages := Paginate(ctx, nil, func(ctx context.Context, opts *GetOptions) ([]Server, *Response, error) {
+		servers, r, err := s.list(withOperation(ctx, "Servers.List"), request.ProjectID, opts, nil)
+		resp = r
+		return servers, r, err
+	})
+
+	for srv, err := range pages {
+		if err != nil {
+			return nil, nil, err
+		}
+
+		created, err := time.Parse(time.RFC3339, srv.Created)
+		if err != nil || created.Before(since) {
+			continue
+		}
+		if request.Hostname != "" && srv.Hostname != request.Hostname {
+			continue
+		}
+		if request.Tags != nil && !hasTags(srv.Tags, *request.Tags) {
+			continue
+		}
+
+		return &srv, resp, nil
+	}
+
+	return nil, nil, nil
+}
+
+func hasTags(tags, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := tags[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ServersClient) create(ctx context.Context, request *CreateServer, callOpts ...CallOption) (Server, *Response, error) {
 	var trans Server
 	path := fmt.Sprintf("/v1/projects/%d/servers", request.ProjectID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -416,11 +570,11 @@ func (s *ServersClient) Create(ctx context.Context, request *CreateServer) (Serv
 }
 
 // Update server.
-func (s *ServersClient) Update(ctx context.Context, serverID int, request *UpdateServer) (Server, *Response, error) {
+func (s *ServersClient) Update(ctx context.Context, serverID int, request *UpdateServer, callOpts ...CallOption) (Server, *Response, error) {
 	var trans Server
 	path := fmt.Sprintf("%s/%d", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return Server{}, nil, err
 	}
@@ -430,10 +584,10 @@ func (s *ServersClient) Update(ctx context.Context, serverID int, request *Updat
 }
 
 // Delete server.
-func (s *ServersClient) Delete(ctx context.Context, serverID int) (*Response, error) {
+func (s *ServersClient) Delete(ctx context.Context, serverID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d", baseServerPath, serverID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.Delete"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -443,11 +597,11 @@ func (s *ServersClient) Delete(ctx context.Context, serverID int) (*Response, er
 }
 
 // ListSSHKeys list SSH keys assigned to the server.
-func (s *ServersClient) ListSSHKeys(ctx context.Context, serverID int, opts *GetOptions) ([]SSHKey, *Response, error) {
+func (s *ServersClient) ListSSHKeys(ctx context.Context, serverID int, opts *GetOptions, callOpts ...CallOption) ([]SSHKey, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%d/ssh-keys", baseServerPath, serverID))
 	var trans []SSHKey
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.ListSSHKeys"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -456,12 +610,19 @@ func (s *ServersClient) ListSSHKeys(ctx context.Context, serverID int, opts *Get
 	return trans, resp, err
 }
 
+// AllSSHKeys iterates over all SSH keys assigned to the server, fetching pages lazily.
+func (s *ServersClient) AllSSHKeys(ctx context.Context, serverID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[SSHKey, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]SSHKey, *Response, error) {
+		return s.ListSSHKeys(ctx, serverID, opts, callOpts...)
+	})
+}
+
 // ListCycles lists available billing cycles.
-func (s *ServersClient) ListCycles(ctx context.Context, opts *GetOptions) ([]ServerCycle, *Response, error) {
+func (s *ServersClient) ListCycles(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]ServerCycle, *Response, error) {
 	path := opts.WithQuery("cycles")
 	var trans []ServerCycle
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Servers.ListCycles"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -470,20 +631,35 @@ func (s *ServersClient) ListCycles(ctx context.Context, opts *GetOptions) ([]Ser
 	return trans, resp, err
 }
 
+// AllCycles iterates over all available billing cycles, fetching pages lazily.
+func (s *ServersClient) AllCycles(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[ServerCycle, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]ServerCycle, *Response, error) {
+		return s.ListCycles(ctx, opts, callOpts...)
+	})
+}
+
 // WaitForStatus blocks until server reaches specified status.
-// Returns an error if the server has a failing status.
-func (s *ServersClient) WaitForStatus(ctx context.Context, serverID int, status ServerStatus) (Server, *Response, error) {
+// Returns an error if the server has a failing status, or if the client
+// poll backoff returns [backoff.Stop] before the status is reached.
+func (s *ServersClient) WaitForStatus(ctx context.Context, serverID int, status ServerStatus, callOpts ...CallOption) (Server, *Response, error) {
 	if s.client.pollBackoff == nil {
 		return Server{}, nil, errors.New("nil client pollBackoff function")
 	}
 
 	attempt := 0
 	for {
-		server, resp, err := s.Get(ctx, serverID, nil)
+		server, resp, err := s.Get(ctx, serverID, nil, callOpts...)
 		if err != nil {
 			return Server{}, resp, err
 		}
 
+		s.client.observePoll(ctx, PollInfo{
+			Operation: "Servers.WaitForStatus",
+			Iteration: attempt + 1,
+			Status:    server.Status,
+			Done:      server.Status == status.String() || server.Status == StatusFailed.String(),
+		})
+
 		if server.Status == status.String() {
 			return server, resp, nil
 		}
@@ -493,60 +669,17 @@ func (s *ServersClient) WaitForStatus(ctx context.Context, serverID int, status
 			return server, resp, err
 		}
 
+		delay := s.client.pollBackoff(attempt, resp.Response)
+		if delay == backoff.Stop {
+			err = fmt.Errorf("server %d did not reach status %q, last status %q", serverID, status, server.Status)
+			return server, resp, err
+		}
+
 		select {
-		case <-time.After(s.client.pollBackoff(attempt, resp.Response)):
+		case <-time.After(delay):
 			attempt++
 		case <-ctx.Done():
 			return Server{}, resp, ctx.Err()
 		}
 	}
 }
-
-// GeneratePassword generates a password that matches Cherry Servers secure password
-// criteria in a cryptographically secure way.
-//
-// The requirements are as follows:
-//
-// - At least 8 characters (this function will generate a 20 character long password).
-//
-// - Must include at least one letter.
-//
-// - Must include at least one capital letter that is not the first character.
-//
-// - Must include at least one number that is not the last character.
-//
-// - Forbidden characters ' " ` ! $ % & ; % #.
-func GeneratePassword() (string, error) {
-	const (
-		lowercase = "abcdefghijklmnopqrstuvwxyz"
-		uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
-		digits    = "0123456789"
-		all       = lowercase + uppercase + digits
-		length    = 20
-	)
-	password := make([]byte, length)
-
-	var charset string
-	for i := range length {
-		switch i {
-		case 0:
-			// Ensure there is at least one lower-case alphabetical character.
-			charset = lowercase
-		case 1:
-			// Ensure there is at least one upper-case alphabetical
-			// character that is not first.
-			charset = uppercase
-		case 2:
-			// Ensure there is at least one digit that is not last.
-			charset = digits
-		default:
-			charset = all
-		}
-		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
-		if err != nil {
-			return "", err
-		}
-		password[i] = charset[idx.Int64()]
-	}
-	return string(password), nil
-}
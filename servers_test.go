@@ -792,6 +792,38 @@ func TestServer_WaitForStatusReturnsErrorWhenDeploymentFails(t *testing.T) {
 	assert.Equal(t, 3, pollCount)
 }
 
+func TestServer_WaitForStatusReturnsErrorWhenBackoffStops(t *testing.T) {
+	mux := http.NewServeMux()
+	apiServer := httptest.NewServer(mux)
+	defer apiServer.Close()
+
+	var pollF backoff.Func = func(_ int, _ *http.Response) time.Duration {
+		return 0
+	}
+
+	client, err := NewClient(WithAPIKey(
+		"fakeKey"),
+		WithPollBackoff(backoff.MaxAttempts(pollF, 3)),
+		WithURL(apiServer.URL),
+	)
+	require.NoError(t, err)
+
+	pollCount := 0
+	mux.HandleFunc("GET /v1/servers/123", func(w http.ResponseWriter, _ *http.Request) {
+		pollCount++
+		_, err := fmt.Fprint(w, `{"id": 123, "status": "deploying"}`)
+		require.NoError(t, err)
+	})
+
+	srv, resp, err := client.Servers.WaitForStatus(t.Context(), 123, StatusDeployed)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `last status "deploying"`)
+	assert.NotNil(t, resp)
+	assert.Equal(t, "deploying", srv.Status)
+	assert.Equal(t, 3, pollCount)
+}
+
 func TestGeneratePasswordGeneratesValidCherryServersPasswords(t *testing.T) {
 	const minLen = 16
 	var failures []string
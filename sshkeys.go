@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,11 +12,12 @@ const baseSSHPath = "/v1/ssh-keys"
 // SSHKeysService is an interface for interfacing with the the SSH keys endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/SshKeys
 type SSHKeysService interface {
-	List(ctx context.Context, opts *GetOptions) ([]SSHKey, *Response, error)
-	Get(ctx context.Context, sshKeyID int, opts *GetOptions) (SSHKey, *Response, error)
-	Create(ctx context.Context, request *CreateSSHKey) (SSHKey, *Response, error)
-	Delete(ctx context.Context, sshKeyID int) (*Response, error)
-	Update(ctx context.Context, sshKeyID int, request *UpdateSSHKey) (SSHKey, *Response, error)
+	List(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]SSHKey, *Response, error)
+	All(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[SSHKey, error]
+	Get(ctx context.Context, sshKeyID int, opts *GetOptions, callOpts ...CallOption) (SSHKey, *Response, error)
+	Create(ctx context.Context, request *CreateSSHKey, callOpts ...CallOption) (SSHKey, *Response, error)
+	Delete(ctx context.Context, sshKeyID int, callOpts ...CallOption) (*Response, error)
+	Update(ctx context.Context, sshKeyID int, request *UpdateSSHKey, callOpts ...CallOption) (SSHKey, *Response, error)
 }
 
 // SSHKey data.
@@ -48,11 +50,11 @@ type UpdateSSHKey struct {
 }
 
 // List all SSH keys.
-func (s *SSHKeysClient) List(ctx context.Context, opts *GetOptions) ([]SSHKey, *Response, error) {
+func (s *SSHKeysClient) List(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]SSHKey, *Response, error) {
 	var trans []SSHKey
 	pathQuery := opts.WithQuery(baseSSHPath)
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, pathQuery, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "SSHKeys.List"), http.MethodGet, pathQuery, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -61,12 +63,19 @@ func (s *SSHKeysClient) List(ctx context.Context, opts *GetOptions) ([]SSHKey, *
 	return trans, resp, err
 }
 
+// All iterates over all SSH keys, fetching pages lazily.
+func (s *SSHKeysClient) All(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[SSHKey, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]SSHKey, *Response, error) {
+		return s.List(ctx, opts, callOpts...)
+	})
+}
+
 // Get an SSH key.
-func (s *SSHKeysClient) Get(ctx context.Context, sshKeyID int, opts *GetOptions) (SSHKey, *Response, error) {
+func (s *SSHKeysClient) Get(ctx context.Context, sshKeyID int, opts *GetOptions, callOpts ...CallOption) (SSHKey, *Response, error) {
 	var trans SSHKey
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", baseSSHPath, sshKeyID))
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "SSHKeys.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return SSHKey{}, nil, err
 	}
@@ -76,10 +85,10 @@ func (s *SSHKeysClient) Get(ctx context.Context, sshKeyID int, opts *GetOptions)
 }
 
 // Create a new SSH key.
-func (s *SSHKeysClient) Create(ctx context.Context, request *CreateSSHKey) (SSHKey, *Response, error) {
+func (s *SSHKeysClient) Create(ctx context.Context, request *CreateSSHKey, callOpts ...CallOption) (SSHKey, *Response, error) {
 	var trans SSHKey
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, baseSSHPath, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "SSHKeys.Create"), http.MethodPost, baseSSHPath, request, callOpts...)
 	if err != nil {
 		return SSHKey{}, nil, err
 	}
@@ -89,10 +98,10 @@ func (s *SSHKeysClient) Create(ctx context.Context, request *CreateSSHKey) (SSHK
 }
 
 // Delete removes desired SSH key by its ID.
-func (s *SSHKeysClient) Delete(ctx context.Context, sshKeyID int) (*Response, error) {
+func (s *SSHKeysClient) Delete(ctx context.Context, sshKeyID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d", baseSSHPath, sshKeyID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "SSHKeys.Delete"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,11 +111,11 @@ func (s *SSHKeysClient) Delete(ctx context.Context, sshKeyID int) (*Response, er
 }
 
 // Update an SSH key.
-func (s *SSHKeysClient) Update(ctx context.Context, sshKeyID int, request *UpdateSSHKey) (SSHKey, *Response, error) {
+func (s *SSHKeysClient) Update(ctx context.Context, sshKeyID int, request *UpdateSSHKey, callOpts ...CallOption) (SSHKey, *Response, error) {
 	var trans SSHKey
 	path := fmt.Sprintf("%s/%d", baseSSHPath, sshKeyID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "SSHKeys.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return SSHKey{}, nil, err
 	}
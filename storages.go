@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,13 +12,14 @@ const baseStoragePath = "/v1/storages"
 // StoragesService is an interface for interfacing with the Storages endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Storage
 type StoragesService interface {
-	List(ctx context.Context, projectID int, opts *GetOptions) ([]BlockStorage, *Response, error)
-	Get(ctx context.Context, storageID int, opts *GetOptions) (BlockStorage, *Response, error)
-	Create(ctx context.Context, projectID int, request *CreateStorage) (BlockStorage, *Response, error)
-	Delete(ctx context.Context, storageID int) (*Response, error)
-	Attach(ctx context.Context, storageID int, request *AttachTo) (BlockStorage, *Response, error)
-	Detach(ctx context.Context, storageID int) (*Response, error)
-	Update(ctx context.Context, storageID int, request *UpdateStorage) (BlockStorage, *Response, error)
+	List(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]BlockStorage, *Response, error)
+	All(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[BlockStorage, error]
+	Get(ctx context.Context, storageID int, opts *GetOptions, callOpts ...CallOption) (BlockStorage, *Response, error)
+	Create(ctx context.Context, projectID int, request *CreateStorage, callOpts ...CallOption) (BlockStorage, *Response, error)
+	Delete(ctx context.Context, storageID int, callOpts ...CallOption) (*Response, error)
+	Attach(ctx context.Context, storageID int, request *AttachTo, callOpts ...CallOption) (BlockStorage, *Response, error)
+	Detach(ctx context.Context, storageID int, callOpts ...CallOption) (*Response, error)
+	Update(ctx context.Context, storageID int, request *UpdateStorage, callOpts ...CallOption) (BlockStorage, *Response, error)
 }
 
 // BlockStorage data.
@@ -67,26 +69,51 @@ type StoragesClient struct {
 	client *Client
 }
 
-// List all project storages.
-func (s *StoragesClient) List(ctx context.Context, projectID int, opts *GetOptions) ([]BlockStorage, *Response, error) {
-	path := opts.WithQuery(fmt.Sprintf("%s/%d/storages", baseProjectPath, projectID))
+// List all project storages. See [StorageFilter] for filtering them.
+func (s *StoragesClient) List(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) ([]BlockStorage, *Response, error) {
+	filter, callOpts, err := listFilter[BlockStorage](callOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trans, resp, err := s.list(withOperation(ctx, "Storages.List"), projectID, opts, filter, callOpts...)
+	return filterItems(trans, filter), resp, err
+}
+
+// All iterates over all project storages, fetching pages lazily.
+func (s *StoragesClient) All(ctx context.Context, projectID int, opts *GetOptions, callOpts ...CallOption) iter.Seq2[BlockStorage, error] {
+	filter, callOpts, err := listFilter[BlockStorage](callOpts)
+	if err != nil {
+		return errSeq[BlockStorage](err)
+	}
+
+	// Filter the iterator rather than the pages,
+	// so short filtered pages don't end pagination.
+	return filterSeq(Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]BlockStorage, *Response, error) {
+		return s.list(withOperation(ctx, "Storages.List"), projectID, opts, filter, callOpts...)
+	}), filter)
+}
+
+func (s *StoragesClient) list(ctx context.Context, projectID int, opts *GetOptions, filter ListFilter, callOpts ...CallOption) ([]BlockStorage, *Response, error) {
+	path := opts.WithQuery(fmt.Sprintf("%s/%d/storages", baseProjectPath, s.client.projectID(projectID)))
 	var trans []BlockStorage
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
+	applyFilter(req, filter)
 
 	resp, err := s.client.Do(req, &trans)
 	return trans, resp, err
 }
 
 // Get storage instance.
-func (s *StoragesClient) Get(ctx context.Context, storageID int, opts *GetOptions) (BlockStorage, *Response, error) {
+func (s *StoragesClient) Get(ctx context.Context, storageID int, opts *GetOptions, callOpts ...CallOption) (BlockStorage, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", baseStoragePath, storageID))
 	var trans BlockStorage
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Storages.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return BlockStorage{}, nil, err
 	}
@@ -96,11 +123,18 @@ func (s *StoragesClient) Get(ctx context.Context, storageID int, opts *GetOption
 }
 
 // Create storage instance.
-func (s *StoragesClient) Create(ctx context.Context, projectID int, request *CreateStorage) (BlockStorage, *Response, error) {
+func (s *StoragesClient) Create(ctx context.Context, projectID int, request *CreateStorage, callOpts ...CallOption) (BlockStorage, *Response, error) {
+	request = s.client.Defaults.applyToStorage(request)
+	if request != nil && s.client.shouldValidate(callOpts) {
+		if err := request.Validate(); err != nil {
+			return BlockStorage{}, nil, err
+		}
+	}
+
 	var trans BlockStorage
-	path := fmt.Sprintf("%s/%d/storages", baseProjectPath, projectID)
+	path := fmt.Sprintf("%s/%d/storages", baseProjectPath, s.client.projectID(projectID))
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Storages.Create"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return BlockStorage{}, nil, err
 	}
@@ -110,10 +144,10 @@ func (s *StoragesClient) Create(ctx context.Context, projectID int, request *Cre
 }
 
 // Delete storage.
-func (s *StoragesClient) Delete(ctx context.Context, storageID int) (*Response, error) {
+func (s *StoragesClient) Delete(ctx context.Context, storageID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d", baseStoragePath, storageID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Storages.Delete"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,11 +157,11 @@ func (s *StoragesClient) Delete(ctx context.Context, storageID int) (*Response,
 }
 
 // Attach storage to server.
-func (s *StoragesClient) Attach(ctx context.Context, storageID int, request *AttachTo) (BlockStorage, *Response, error) {
+func (s *StoragesClient) Attach(ctx context.Context, storageID int, request *AttachTo, callOpts ...CallOption) (BlockStorage, *Response, error) {
 	var trans BlockStorage
 	path := fmt.Sprintf("%s/%d/attachments", baseStoragePath, storageID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Storages.Attach"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return BlockStorage{}, nil, err
 	}
@@ -137,10 +171,10 @@ func (s *StoragesClient) Attach(ctx context.Context, storageID int, request *Att
 }
 
 // Detach storage from server.
-func (s *StoragesClient) Detach(ctx context.Context, storageID int) (*Response, error) {
+func (s *StoragesClient) Detach(ctx context.Context, storageID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d/attachments", baseStoragePath, storageID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Storages.Detach"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -150,11 +184,11 @@ func (s *StoragesClient) Detach(ctx context.Context, storageID int) (*Response,
 }
 
 // Update storage.
-func (s *StoragesClient) Update(ctx context.Context, storageID int, request *UpdateStorage) (BlockStorage, *Response, error) {
+func (s *StoragesClient) Update(ctx context.Context, storageID int, request *UpdateStorage, callOpts ...CallOption) (BlockStorage, *Response, error) {
 	var trans BlockStorage
 	path := fmt.Sprintf("%s/%d", baseStoragePath, storageID)
 
-	req, err := s.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := s.client.NewRequest(withOperation(ctx, "Storages.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return BlockStorage{}, nil, err
 	}
@@ -3,6 +3,7 @@ package cherrygo
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -11,11 +12,12 @@ const teamsPath = "/v1/teams"
 // TeamsService is an interface for interfacing with the Teams endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Teams
 type TeamsService interface {
-	List(ctx context.Context, opts *GetOptions) ([]Team, *Response, error)
-	Get(ctx context.Context, teamID int, opts *GetOptions) (Team, *Response, error)
-	Create(ctx context.Context, request *CreateTeam) (Team, *Response, error)
-	Update(ctx context.Context, teamID int, request *UpdateTeam) (Team, *Response, error)
-	Delete(ctx context.Context, teamID int) (*Response, error)
+	List(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]Team, *Response, error)
+	All(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Team, error]
+	Get(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) (Team, *Response, error)
+	Create(ctx context.Context, request *CreateTeam, callOpts ...CallOption) (Team, *Response, error)
+	Update(ctx context.Context, teamID int, request *UpdateTeam, callOpts ...CallOption) (Team, *Response, error)
+	Delete(ctx context.Context, teamID int, callOpts ...CallOption) (*Response, error)
 }
 
 // Team data.
@@ -106,11 +108,11 @@ type UpdateTeam struct {
 }
 
 // List func lists teams
-func (c *TeamsClient) List(ctx context.Context, opts *GetOptions) ([]Team, *Response, error) {
+func (c *TeamsClient) List(ctx context.Context, opts *GetOptions, callOpts ...CallOption) ([]Team, *Response, error) {
 	var trans []Team
 	pathQuery := opts.WithQuery(teamsPath)
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, pathQuery, nil)
+	req, err := c.client.NewRequest(withOperation(ctx, "Teams.List"), http.MethodGet, pathQuery, nil, callOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -119,12 +121,19 @@ func (c *TeamsClient) List(ctx context.Context, opts *GetOptions) ([]Team, *Resp
 	return trans, resp, err
 }
 
+// All iterates over all teams, fetching pages lazily.
+func (c *TeamsClient) All(ctx context.Context, opts *GetOptions, callOpts ...CallOption) iter.Seq2[Team, error] {
+	return Paginate(ctx, opts, func(ctx context.Context, opts *GetOptions) ([]Team, *Response, error) {
+		return c.List(ctx, opts, callOpts...)
+	})
+}
+
 // Get a team.
-func (c *TeamsClient) Get(ctx context.Context, teamID int, opts *GetOptions) (Team, *Response, error) {
+func (c *TeamsClient) Get(ctx context.Context, teamID int, opts *GetOptions, callOpts ...CallOption) (Team, *Response, error) {
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", teamsPath, teamID))
 	var trans Team
 
-	req, err := c.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.client.NewRequest(withOperation(ctx, "Teams.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return Team{}, nil, err
 	}
@@ -134,11 +143,11 @@ func (c *TeamsClient) Get(ctx context.Context, teamID int, opts *GetOptions) (Te
 }
 
 // Create a team.
-func (c *TeamsClient) Create(ctx context.Context, request *CreateTeam) (Team, *Response, error) {
+func (c *TeamsClient) Create(ctx context.Context, request *CreateTeam, callOpts ...CallOption) (Team, *Response, error) {
 	path := teamsPath
 	var trans Team
 
-	req, err := c.client.NewRequest(ctx, http.MethodPost, path, request)
+	req, err := c.client.NewRequest(withOperation(ctx, "Teams.Create"), http.MethodPost, path, request, callOpts...)
 	if err != nil {
 		return Team{}, nil, err
 	}
@@ -148,11 +157,11 @@ func (c *TeamsClient) Create(ctx context.Context, request *CreateTeam) (Team, *R
 }
 
 // Update a team.
-func (c *TeamsClient) Update(ctx context.Context, teamID int, request *UpdateTeam) (Team, *Response, error) {
+func (c *TeamsClient) Update(ctx context.Context, teamID int, request *UpdateTeam, callOpts ...CallOption) (Team, *Response, error) {
 	path := fmt.Sprintf("%s/%d", teamsPath, teamID)
 	var trans Team
 
-	req, err := c.client.NewRequest(ctx, http.MethodPut, path, request)
+	req, err := c.client.NewRequest(withOperation(ctx, "Teams.Update"), http.MethodPut, path, request, callOpts...)
 	if err != nil {
 		return Team{}, nil, err
 	}
@@ -162,10 +171,10 @@ func (c *TeamsClient) Update(ctx context.Context, teamID int, request *UpdateTea
 }
 
 // Delete a team.
-func (c *TeamsClient) Delete(ctx context.Context, teamID int) (*Response, error) {
+func (c *TeamsClient) Delete(ctx context.Context, teamID int, callOpts ...CallOption) (*Response, error) {
 	path := fmt.Sprintf("%s/%d", teamsPath, teamID)
 
-	req, err := c.client.NewRequest(ctx, http.MethodDelete, path, nil)
+	req, err := c.client.NewRequest(withOperation(ctx, "Teams.Delete"), http.MethodDelete, path, nil, callOpts...)
 	if err != nil {
 		return nil, err
 	}
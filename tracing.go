@@ -0,0 +1,138 @@
+package cherrygo
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cherryservers/cherrygo/v4/internal/client"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cherryservers/cherrygo/v4"
+
+// WithTracerProvider enables OpenTelemetry tracing with spans from tp.
+//
+// A client span is started for every logical call, named after the service
+// method, e.g. "cherrygo.Servers.Create", with a child span for every HTTP attempt.
+// Spans carry the resource IDs from the request path, the response status,
+// the retry count and backoff delays. W3C trace context headers are
+// propagated with every attempt.
+//
+// Tracing is disabled by default and costs nothing when disabled.
+func WithTracerProvider(tp trace.TracerProvider) ClientOpt {
+	return func(c *options) error {
+		if tp == nil {
+			return errors.New("tracer provider must not be nil")
+		}
+
+		t := &tracer{
+			tracer:     tp.Tracer(tracerName),
+			propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		}
+
+		// Tracing must be the outermost call middleware,
+		// so spans cover any other middleware.
+		c.clientOpts = append([]client.Option{client.WithCallMiddleware(t.call)}, c.clientOpts...)
+		c.clientOpts = append(c.clientOpts,
+			client.WithAttemptMiddleware(t.attempt),
+			client.WithRetryObserver(t.retry),
+		)
+		return nil
+	}
+}
+
+type tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// call starts a span for a logical call.
+func (t *tracer) call(next client.Doer) client.Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		route := requestRoute(req)
+
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.template", route.templatedPath()),
+			attribute.String("server.address", req.URL.Hostname()),
+		}
+		for name, value := range route.params {
+			attrs = append(attrs, attribute.String("cherrygo."+name, value))
+		}
+
+		ctx, span := t.tracer.Start(req.Context(), "cherrygo."+route.operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		resp, err := next.Do(req.WithContext(ctx))
+
+		if stats := client.StatsFromContext(ctx); stats != nil {
+			span.SetAttributes(
+				attribute.Int("cherrygo.retry.count", max(stats.Attempts-1, 0)),
+				attribute.Float64("cherrygo.retry.backoff_seconds", stats.Backoff.Seconds()),
+			)
+		}
+		endSpan(span, resp, err)
+
+		return resp, err
+	})
+}
+
+// attempt starts a span for a single HTTP attempt and propagates the trace context.
+func (t *tracer) attempt(next client.Doer) client.Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		attrs := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+		}
+		if stats := client.StatsFromContext(req.Context()); stats != nil && stats.Attempts > 1 {
+			attrs = append(attrs, attribute.Int("http.request.resend_count", stats.Attempts-1))
+		}
+
+		ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		req = req.WithContext(ctx)
+		t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		resp, err := next.Do(req)
+		endSpan(span, resp, err)
+
+		return resp, err
+	})
+}
+
+// retry records scheduled retries as events of the logical call span.
+func (t *tracer) retry(ev client.RetryEvent) {
+	span := trace.SpanFromContext(ev.Request.Context())
+
+	attrs := []attribute.KeyValue{
+		attribute.Int("cherrygo.retry.attempt", ev.Attempt),
+		attribute.Float64("cherrygo.retry.delay_seconds", ev.Delay.Seconds()),
+	}
+	if ev.Err != nil {
+		attrs = append(attrs, attribute.String("error.message", ev.Err.Error()))
+	}
+	span.AddEvent("retry scheduled", trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, resp *http.Response, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+}
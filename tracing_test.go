@@ -0,0 +1,188 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing(t *testing.T) {
+	setup()
+	defer teardown()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c, err := NewClient(
+		WithURL(server.URL),
+		WithTracerProvider(tp),
+		WithRetryBackoff(func(int, *http.Response) time.Duration { return time.Millisecond }),
+	)
+	require.NoError(t, err)
+
+	var traceparents []string
+	mux.HandleFunc("/v1/servers/383531", func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("Traceparent"))
+		if len(traceparents) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := fmt.Fprint(w, `{"id": 383531}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Get(t.Context(), 383531, nil)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	// Attempt spans end first.
+	first, second, call := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "cherrygo.Servers.Get", call.Name)
+	assert.Equal(t, "/v1/servers/{server_id}", spanAttr(call, "url.template").AsString())
+	assert.Equal(t, "383531", spanAttr(call, "cherrygo.server_id").AsString())
+	assert.Equal(t, int64(200), spanAttr(call, "http.response.status_code").AsInt64())
+	assert.Equal(t, int64(1), spanAttr(call, "cherrygo.retry.count").AsInt64())
+	require.Len(t, call.Events, 1)
+	assert.Equal(t, "retry scheduled", call.Events[0].Name)
+
+	for _, attempt := range []tracetest.SpanStub{first, second} {
+		assert.Equal(t, "HTTP GET", attempt.Name)
+		assert.Equal(t, call.SpanContext.SpanID(), attempt.Parent.SpanID())
+		assert.Equal(t, call.SpanContext.TraceID(), attempt.SpanContext.TraceID())
+	}
+	assert.Equal(t, codes.Error, first.Status.Code)
+	assert.Equal(t, int64(503), spanAttr(first, "http.response.status_code").AsInt64())
+	assert.Equal(t, int64(1), spanAttr(second, "http.request.resend_count").AsInt64())
+
+	require.Len(t, traceparents, 2)
+	assert.Contains(t, traceparents[0], first.SpanContext.SpanID().String())
+	assert.Contains(t, traceparents[1], second.SpanContext.SpanID().String())
+}
+
+func TestTracingErrorStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c, err := NewClient(WithURL(server.URL), WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/projects/1/servers", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	_, _, err = c.Servers.Create(t.Context(), &CreateServer{ProjectID: 1})
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "cherrygo.Servers.Create", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "1", spanAttr(spans[1], "cherrygo.project_id").AsString())
+}
+
+func TestTracingOperationNames(t *testing.T) {
+	setup()
+	defer teardown()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c, err := NewClient(WithURL(server.URL), WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	mux.HandleFunc("/v1/servers/1/actions", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/plans/", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": 1}`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/v1/ips/", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"id": "1"}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Reboot(t.Context(), 1)
+	require.NoError(t, err)
+	_, _, err = c.Servers.Reinstall(t.Context(), 1, &ReinstallServerFields{Image: "ubuntu", Password: "hunter2Hunter2x"})
+	require.NoError(t, err)
+	_, _, err = c.Plans.GetByID(t.Context(), 1, nil)
+	require.NoError(t, err)
+	_, _, err = c.Plans.GetBySlug(t.Context(), "e5_1620v4", nil)
+	require.NoError(t, err)
+	_, _, err = c.IPAddresses.Assign(t.Context(), "1", &AssignIPAddress{ServerID: 1})
+	require.NoError(t, err)
+	_, err = c.IPAddresses.Unassign(t.Context(), "1")
+	require.NoError(t, err)
+
+	// Requests that aren't made by service methods are named after the route.
+	req, err := c.NewRequest(t.Context(), http.MethodPost, "/v1/servers/1/actions", ServerAction{Type: "reboot"})
+	require.NoError(t, err)
+	_, err = c.Do(req, nil)
+	require.NoError(t, err)
+
+	var names []string
+	for _, span := range exporter.GetSpans() {
+		// Skip the attempt spans, which are children of the call spans.
+		if !span.Parent.IsValid() {
+			names = append(names, span.Name)
+		}
+	}
+	assert.Equal(t, []string{
+		"cherrygo.Servers.Reboot",
+		"cherrygo.Servers.Reinstall",
+		"cherrygo.Plans.GetByID",
+		"cherrygo.Plans.GetBySlug",
+		"cherrygo.IPAddresses.Assign",
+		"cherrygo.IPAddresses.Unassign",
+		"cherrygo.Servers.Action",
+	}, names)
+}
+
+func TestMatchRoute(t *testing.T) {
+	cases := []struct {
+		method        string
+		path          string
+		wantOperation string
+		wantTemplate  string
+	}{
+		{"GET", "/v1/teams/1/plans", "Plans.List", "/v1/teams/{team_id}/plans"},
+		{"GET", "/v1/plans/e5_1620v4", "Plans.Get", "/v1/plans/{plan}"},
+		{"GET", "/v1/plans/e5_1620v4/images", "Images.List", "/v1/plans/{plan}/images"},
+		{"DELETE", "/v1/storages/2/attachments", "Storages.Detach", "/v1/storages/{storage_id}/attachments"},
+		{"GET", "/cycles", "Servers.ListCycles", "/v1/cycles"},
+		{"GET", "/v1/unknown/1", "GET", "/v1/unknown/1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			r := matchRoute(tc.method, tc.path)
+			assert.Equal(t, tc.wantOperation, r.operation)
+			assert.Equal(t, tc.wantTemplate, r.templatedPath())
+		})
+	}
+}
@@ -11,8 +11,8 @@ const baseUserPath = "/v1/users"
 // UsersService is an interface for interfacing with the the User endpoints of the CherryServers API
 // See: https://api.cherryservers.com/doc/#tag/Users
 type UsersService interface {
-	CurrentUser(ctx context.Context, opts *GetOptions) (User, *Response, error)
-	Get(ctx context.Context, userID int, opts *GetOptions) (User, *Response, error)
+	CurrentUser(ctx context.Context, opts *GetOptions, callOpts ...CallOption) (User, *Response, error)
+	Get(ctx context.Context, userID int, opts *GetOptions, callOpts ...CallOption) (User, *Response, error)
 }
 
 // User is the Cherry Servers user account.
@@ -34,11 +34,11 @@ type UsersClient struct {
 }
 
 // CurrentUser gets current user based on the API key.
-func (s *UsersClient) CurrentUser(ctx context.Context, opts *GetOptions) (User, *Response, error) {
+func (s *UsersClient) CurrentUser(ctx context.Context, opts *GetOptions, callOpts ...CallOption) (User, *Response, error) {
 	var trans User
 	path := opts.WithQuery("/v1/user")
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Users.CurrentUser"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return User{}, nil, err
 	}
@@ -48,11 +48,11 @@ func (s *UsersClient) CurrentUser(ctx context.Context, opts *GetOptions) (User,
 }
 
 // Get a user.
-func (s *UsersClient) Get(ctx context.Context, userID int, opts *GetOptions) (User, *Response, error) {
+func (s *UsersClient) Get(ctx context.Context, userID int, opts *GetOptions, callOpts ...CallOption) (User, *Response, error) {
 	var trans User
 	path := opts.WithQuery(fmt.Sprintf("%s/%d", baseUserPath, userID))
 
-	req, err := s.client.NewRequest(ctx, http.MethodGet, path, nil)
+	req, err := s.client.NewRequest(withOperation(ctx, "Users.Get"), http.MethodGet, path, nil, callOpts...)
 	if err != nil {
 		return User{}, nil, err
 	}
@@ -0,0 +1,196 @@
+package cherrygo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError is a problem with a field of a request.
+type FieldError struct {
+	// Field is the JSON name of the field, e.g. "hostname".
+	Field string
+
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned by the Validate methods of requests,
+// listing every problem found rather than just the first.
+type ValidationError struct {
+	// Request is the name of the request type, e.g. "CreateServer".
+	Request string
+
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Request, strings.Join(msgs, "; "))
+}
+
+// validation collects the field errors of a request.
+type validation struct {
+	request string
+	errs    []FieldError
+}
+
+func (v *validation) add(field, format string, args ...any) {
+	v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) required(field, value string) {
+	if value == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validation) hostname(field, value string) {
+	if value != "" && !isHostname(value) {
+		v.add(field, "%q is not a valid RFC 1123 hostname", value)
+	}
+}
+
+func (v *validation) password(field, value string) {
+	if value == "" {
+		v.add(field, "is required")
+		return
+	}
+	var perr *PasswordError
+	if errors.As(ValidatePassword(value), &perr) {
+		for _, violation := range perr.Violations {
+			v.add(field, "%s", violation)
+		}
+	}
+}
+
+func (v *validation) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Request: v.request, Errors: v.errs}
+}
+
+// WithValidation makes the client validate create, reinstall and rescue requests
+// before sending them, see e.g. [CreateServer.Validate], and return a
+// [*ValidationError] instead of a round trip to the API. Requests that set
+// an OS partition size also have their plan checked, which takes a request.
+//
+// Requests of dry runs are always validated, see [WithDryRun].
+func WithValidation() ClientOpt {
+	return func(c *options) error {
+		c.validate = true
+		return nil
+	}
+}
+
+// shouldValidate reports whether requests of a call with callOpts are validated.
+func (c *Client) shouldValidate(callOpts []CallOption) bool {
+	return c.validate || c.dryRun || newCallOptions(callOpts).dryRun
+}
+
+// validatePartitionSize checks that plan supports setting the OS partition size.
+func validatePartitionSize(request string, plan Plan, size int) error {
+	v := validation{request: request}
+	if size != 0 && plan.Type != "baremetal" {
+		v.add("os_partition_size", "is not supported by %s plan %q", plan.Type, plan.Slug)
+	}
+	return v.err()
+}
+
+// Validate checks the request for mistakes the API would reject,
+// such as a missing plan or region or an invalid hostname.
+// Whether the plan supports OSPartitionSize is only checked by
+// clients with [WithValidation], since it takes a request.
+func (r *CreateServer) Validate() error {
+	v := validation{request: "CreateServer"}
+	if r.ProjectID <= 0 {
+		v.add("project_id", "is required")
+	}
+	v.required("plan", r.Plan)
+	if r.PrebuiltID != 0 && r.Plan == "" {
+		v.add("prebuilt_id", "requires plan")
+	}
+	v.required("region", r.Region)
+	v.hostname("hostname", r.Hostname)
+	if r.OSPartitionSize < 0 {
+		v.add("os_partition_size", "must be positive")
+	}
+	return v.err()
+}
+
+// Validate checks the request for mistakes the API would reject,
+// such as a missing image or a password that breaks the rules of [ValidatePassword].
+func (r *ReinstallServerFields) Validate() error {
+	v := validation{request: "ReinstallServerFields"}
+	v.required("image", r.Image)
+	v.hostname("hostname", r.Hostname)
+	v.password("password", r.Password)
+	if r.OSPartitionSize < 0 {
+		v.add("os_partition_size", "must be positive")
+	}
+	return v.err()
+}
+
+// Validate checks that the password follows the rules of [ValidatePassword].
+func (r *RescueServerFields) Validate() error {
+	v := validation{request: "RescueServerFields"}
+	v.password("password", r.Password)
+	return v.err()
+}
+
+// Validate checks that the request has a positive size and a region.
+func (r *CreateStorage) Validate() error {
+	v := validation{request: "CreateStorage"}
+	if r.Size <= 0 {
+		v.add("size", "must be positive")
+	}
+	v.required("region", r.Region)
+	return v.err()
+}
+
+// Validate checks that the request has a region and
+// that the address is either routed or assigned, not both.
+func (r *CreateIPAddress) Validate() error {
+	v := validation{request: "CreateIPAddress"}
+	v.required("region", r.Region)
+	if r.RoutedTo != "" && (r.AssignedTo != "" || r.TargetedTo != "") {
+		v.add("routed_to", "can't be combined with assigned_to or targeted_to")
+	}
+	v.hostname("a_record", r.ARecord)
+	v.hostname("ptr_record", r.PTRRecord)
+	return v.err()
+}
+
+// Validate checks that the request has a backup plan and a region.
+func (r *CreateBackup) Validate() error {
+	v := validation{request: "CreateBackup"}
+	v.required("slug", r.BackupPlanSlug)
+	v.required("region", r.RegionSlug)
+	return v.err()
+}
+
+// isHostname reports whether s is a valid RFC 1123 hostname.
+func isHostname(s string) bool {
+	if len(s) > 253 {
+		return false
+	}
+
+	for label := range strings.SplitSeq(strings.TrimSuffix(s, "."), ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package cherrygo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldErrors(t *testing.T, err error) map[string][]string {
+	t.Helper()
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	fields := make(map[string][]string)
+	for _, fe := range verr.Errors {
+		fields[fe.Field] = append(fields[fe.Field], fe.Message)
+	}
+	return fields
+}
+
+func TestCreateServerValidate(t *testing.T) {
+	valid := CreateServer{ProjectID: 1, Plan: "e5_1620v4", Region: "eu_nord_1", Hostname: "web-1.example.com"}
+	assert.NoError(t, valid.Validate())
+
+	invalid := CreateServer{PrebuiltID: 7, Hostname: "-web_1", OSPartitionSize: -1}
+	err := invalid.Validate()
+	assert.ErrorContains(t, err, "invalid CreateServer: project_id: is required; plan: is required")
+
+	fields := fieldErrors(t, err)
+	assert.Equal(t, []string{"requires plan"}, fields["prebuilt_id"])
+	assert.Equal(t, []string{"is required"}, fields["region"])
+	assert.Equal(t, []string{`"-web_1" is not a valid RFC 1123 hostname`}, fields["hostname"])
+	assert.Contains(t, fields, "os_partition_size")
+}
+
+func TestReinstallServerFieldsValidate(t *testing.T) {
+	valid := ReinstallServerFields{Image: "ubuntu_24_04_64bit", Password: "abcD3fgh"}
+	assert.NoError(t, valid.Validate())
+
+	fields := fieldErrors(t, (&ReinstallServerFields{}).Validate())
+	assert.Equal(t, []string{"is required"}, fields["image"])
+	assert.Equal(t, []string{"is required"}, fields["password"])
+
+	fields = fieldErrors(t, (&ReinstallServerFields{Image: "debian", Password: "Abcdefg!1"}).Validate())
+	assert.Equal(t, []string{
+		"must include a capital letter that is not the first character",
+		"must include a number that is not the last character",
+		"must not include any of '\"`!$%&;#",
+	}, fields["password"])
+}
+
+func TestCreateRequestsValidate(t *testing.T) {
+	assert.NoError(t, (&CreateStorage{Size: 10, Region: "eu_nord_1"}).Validate())
+	assert.Equal(t, map[string][]string{
+		"size":   {"must be positive"},
+		"region": {"is required"},
+	}, fieldErrors(t, (&CreateStorage{}).Validate()))
+
+	assert.NoError(t, (&CreateIPAddress{Region: "eu_nord_1", RoutedTo: "abc"}).Validate())
+	assert.Equal(t, map[string][]string{
+		"region":    {"is required"},
+		"routed_to": {"can't be combined with assigned_to or targeted_to"},
+	}, fieldErrors(t, (&CreateIPAddress{RoutedTo: "abc", TargetedTo: "1"}).Validate()))
+
+	assert.NoError(t, (&CreateBackup{BackupPlanSlug: "backup_50", RegionSlug: "eu_nord_1"}).Validate())
+	assert.Equal(t, map[string][]string{
+		"slug":   {"is required"},
+		"region": {"is required"},
+	}, fieldErrors(t, (&CreateBackup{}).Validate()))
+}
+
+func TestIsHostname(t *testing.T) {
+	for host, valid := range map[string]bool{
+		"web":                    true,
+		"web-1.example.com":      true,
+		"1web.example.com.":      true,
+		"":                       false,
+		"web_1":                  false,
+		"web-":                   false,
+		"web..example.com":       false,
+		string(make([]byte, 64)): false,
+	} {
+		assert.Equal(t, valid, isHostname(host), host)
+	}
+}
+
+func TestWithValidation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	c, err := NewClient(WithURL(server.URL), WithValidation())
+	require.NoError(t, err)
+
+	mux.HandleFunc("/", func(_ http.ResponseWriter, r *http.Request) {
+		t.Errorf("invalid request %s %s was sent", r.Method, r.URL.Path)
+	})
+	mux.HandleFunc("GET /v1/plans/cloud_vps_1", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"slug": "cloud_vps_1", "type": "vps"}`)
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("GET /v1/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "plan", r.URL.Query().Get("fields"))
+		_, err := fmt.Fprint(w, `{"plan": {"slug": "cloud_vps_1", "type": "vps"}}`)
+		require.NoError(t, err)
+	})
+
+	_, _, err = c.Servers.Create(t.Context(), &CreateServer{ProjectID: 1, Plan: "cloud_vps_1"})
+	assert.Equal(t, map[string][]string{"region": {"is required"}}, fieldErrors(t, err))
+
+	_, _, err = c.Servers.Create(t.Context(), &CreateServer{ProjectID: 1, Plan: "cloud_vps_1", Region: "eu_nord_1", OSPartitionSize: 50})
+	assert.Equal(t, map[string][]string{
+		"os_partition_size": {`is not supported by vps plan "cloud_vps_1"`},
+	}, fieldErrors(t, err))
+
+	_, _, err = c.Servers.Reinstall(t.Context(), 1, &ReinstallServerFields{Image: "debian", Password: "abcD3fgh", OSPartitionSize: 50})
+	assert.Contains(t, fieldErrors(t, err), "os_partition_size")
+
+	_, _, err = c.Servers.EnterRescueMode(t.Context(), 1, &RescueServerFields{Password: "password"})
+	assert.Contains(t, fieldErrors(t, err), "password")
+
+	_, _, err = c.Storages.Create(t.Context(), 1, &CreateStorage{Region: "eu_nord_1"})
+	assert.Contains(t, fieldErrors(t, err), "size")
+
+	_, _, err = c.IPAddresses.Create(t.Context(), 1, &CreateIPAddress{})
+	assert.Contains(t, fieldErrors(t, err), "region")
+
+	_, _, err = c.Backups.Create(t.Context(), 1, &CreateBackup{RegionSlug: "eu_nord_1"})
+	assert.Contains(t, fieldErrors(t, err), "slug")
+}
+
+func TestValidationDisabledByDefault(t *testing.T) {
+	setup()
+	defer teardown()
+
+	sent := false
+	mux.HandleFunc("POST /v1/projects/1/storages", func(w http.ResponseWriter, _ *http.Request) {
+		sent = true
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	_, _, err := testClient.Storages.Create(t.Context(), 1, &CreateStorage{})
+	assert.ErrorIs(t, err, ErrUnprocessableEntity)
+	assert.True(t, sent)
+}